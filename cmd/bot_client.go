@@ -35,11 +35,15 @@ func main() {
 	bots := bot.NewBots(game)
 	player := bots.AddBot("Bob")
 
-	err = client.Connect(grpcClient, player.ID(), player.Name, "")
+	err = client.Connect(grpcClient, player.ID(), player.Name, "", backend.TeamNone)
 	if err != nil {
 		log.Fatalf("connect request failed %v", err)
 	}
 	client.Start()
+	// Bots are always ready to play.
+	if client.InLobby() {
+		client.UpdateLobby(backend.TeamNone, "", true)
+	}
 
 	view.Start()
 	bots.Start()
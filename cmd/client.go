@@ -3,9 +3,13 @@ package main
 // Connects to a server for play.
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	termutil "github.com/andrew-d/go-termutil"
 	"github.com/gdamore/tcell"
@@ -28,6 +32,14 @@ type connectInfo struct {
 	PlayerName string
 	Address    string
 	Password   string
+	Team       backend.Team
+	Spectate   bool
+	Room       string
+	// Matchmaking is the game mode to find a match for, if any.
+	Matchmaking string
+	// InterpolationDelay is how far behind the server other players are
+	// shown, to smooth their movement.
+	InterpolationDelay time.Duration
 }
 
 // It feels wrong to have this much frontend code in a command file, but this
@@ -54,12 +66,37 @@ func connectApp(info *connectInfo) *tview.Application {
 		return result
 	}, nil).
 		AddInputField("Server address", ":8888", 32, nil, nil).
+		AddInputField("Room (optional)", "", 16, nil, nil).
 		AddPasswordField("Server password", "", 32, '*', nil).
+		AddDropDown("Team", []string{"Auto", "Red", "Blue"}, 0, nil).
+		AddCheckbox("Spectate", false, nil).
+		AddInputField("Smoothing (ms)", "100", 6, tview.InputFieldInteger, nil).
+		AddDropDown("Find a match", append([]string{"No"}, backend.GameModeNames()...), 0, nil).
 		AddButton("Connect", func() {
 			info.PlayerName = form.GetFormItem(0).(*tview.InputField).GetText()
 			info.Address = form.GetFormItem(1).(*tview.InputField).GetText()
-			info.Password = form.GetFormItem(2).(*tview.InputField).GetText()
-			if info.PlayerName == "" || info.Address == "" {
+			info.Room = form.GetFormItem(2).(*tview.InputField).GetText()
+			info.Password = form.GetFormItem(3).(*tview.InputField).GetText()
+			teamIndex, _ := form.GetFormItem(4).(*tview.DropDown).GetCurrentOption()
+			info.Team = []backend.Team{backend.TeamNone, backend.TeamRed, backend.TeamBlue}[teamIndex]
+			info.Spectate = form.GetFormItem(5).(*tview.Checkbox).IsChecked()
+			smoothing, err := strconv.Atoi(form.GetFormItem(6).(*tview.InputField).GetText())
+			if err != nil || smoothing < 0 {
+				errors.SetText(" Smoothing must be zero or more milliseconds.")
+				return
+			}
+			info.InterpolationDelay = time.Duration(smoothing) * time.Millisecond
+			matchIndex, matchMode := form.GetFormItem(7).(*tview.DropDown).GetCurrentOption()
+			info.Matchmaking = ""
+			if matchIndex > 0 {
+				info.Matchmaking = matchMode
+			}
+			if info.Matchmaking != "" && info.Spectate {
+				errors.SetText(" Spectators can not find a match.")
+				return
+			}
+			// Spectators do not need a player name.
+			if (info.PlayerName == "" && !info.Spectate) || info.Address == "" {
 				errors.SetText(" All fields are required.")
 				return
 			}
@@ -78,6 +115,115 @@ func connectApp(info *connectInfo) *tview.Application {
 	return app
 }
 
+// lobbyApp shows who is waiting for the match to start, and lets the player
+// choose their team and icon before readying up. The app stops once the
+// match starts, or sets quit if the player gives up waiting.
+func lobbyApp(gameClient *client.GameClient, info *connectInfo, lobbyStates <-chan *proto.LobbyState, quit *bool) *tview.Application {
+	app := tview.NewApplication()
+	flex := tview.NewFlex().
+		SetDirection(tview.FlexRow)
+	flex.SetBorder(true).
+		SetTitle("Waiting for the match to start").
+		SetBackgroundColor(backgroundColor)
+	members := tview.NewTextView().
+		SetDynamicColors(true)
+	members.SetBackgroundColor(backgroundColor)
+
+	lobby := gameClient.Lobby()
+	renderMembers := func(lobby *proto.LobbyState) {
+		lines := []string{fmt.Sprintf(" Mode: %s", lobby.Mode), ""}
+		for _, member := range lobby.Members {
+			details := []string{}
+			memberTeam := proto.GetBackendTeam(member.Team)
+			if lobby.Teams && memberTeam != backend.TeamNone {
+				details = append(details, memberTeam.String())
+			}
+			if member.Host {
+				details = append(details, "host")
+			}
+			if member.Id == gameClient.CurrentPlayer.String() {
+				details = append(details, "you")
+			}
+			status := "[red]waiting[white]"
+			if member.Ready {
+				status = "[green]ready[white]"
+			}
+			line := fmt.Sprintf(" %s %s - %s", member.Icon, tview.Escape(member.Name), status)
+			if len(details) > 0 {
+				line += fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+			}
+			lines = append(lines, line)
+		}
+		members.SetText(strings.Join(lines, "\n"))
+	}
+	renderMembers(lobby)
+
+	team := info.Team
+	icon := ""
+	ready := false
+	updateLobby := func() {
+		gameClient.UpdateLobby(team, icon, ready)
+	}
+	form := tview.NewForm()
+	if lobby.Teams {
+		teams := []backend.Team{backend.TeamNone, backend.TeamRed, backend.TeamBlue}
+		teamIndex := 0
+		for i, option := range teams {
+			if option == team {
+				teamIndex = i
+			}
+		}
+		form.AddDropDown("Team", []string{"Auto", "Red", "Blue"}, teamIndex, func(option string, optionIndex int) {
+			team = teams[optionIndex]
+			updateLobby()
+		})
+	}
+	form.AddInputField("Icon", "", 2, func(textCheck string, lastChar rune) bool {
+		return len([]rune(textCheck)) <= 1
+	}, func(text string) {
+		icon = text
+		if icon != "" {
+			updateLobby()
+		}
+	}).
+		AddCheckbox("Ready", false, func(checked bool) {
+			ready = checked
+			updateLobby()
+		}).
+		AddButton("Start", func() {
+			gameClient.StartMatch()
+		}).
+		AddButton("Quit", func() {
+			*quit = true
+			app.Stop()
+		})
+	form.SetLabelColor(textColor).
+		SetButtonBackgroundColor(fieldColor).
+		SetFieldBackgroundColor(fieldColor).
+		SetBackgroundColor(backgroundColor)
+	help := tview.NewTextView().
+		SetText(" The match starts when everyone is ready, or the host presses start")
+	help.SetBackgroundColor(backgroundColor)
+	flex.AddItem(help, 1, 1, false)
+	flex.AddItem(members, 0, 1, false)
+	flex.AddItem(form, 9, 1, false)
+	app.SetRoot(flex, true).SetFocus(form)
+
+	go func() {
+		for lobby := range lobbyStates {
+			if lobby.Started {
+				app.Stop()
+				return
+			}
+			lobby := lobby
+			app.QueueUpdateDraw(func() {
+				renderMembers(lobby)
+			})
+		}
+	}()
+	return app
+}
+
 func main() {
 	if !termutil.Isatty(os.Stdin.Fd()) {
 		panic("this program must be run in a terminal")
@@ -99,17 +245,55 @@ func main() {
 
 	grpcClient := proto.NewGameClient(conn)
 	client := client.NewGameClient(game, view)
+	client.SetInterpolationDelay(info.InterpolationDelay)
+	client.Room = info.Room
+	// Only the latest lobby state matters, so older states are dropped
+	// instead of blocking the client.
+	lobbyStates := make(chan *proto.LobbyState, 1)
+	client.OnLobbyState = func(lobby *proto.LobbyState) {
+		select {
+		case <-lobbyStates:
+		default:
+		}
+		lobbyStates <- lobby
+	}
 
-	playerID := uuid.New()
-	err = client.Connect(grpcClient, playerID, info.PlayerName, info.Password)
+	if info.Spectate {
+		err = client.Spectate(grpcClient, info.Password)
+	} else {
+		// Wait for the server to put us in a room with similar players.
+		if info.Matchmaking != "" {
+			log.Printf("looking for a %s match...", info.Matchmaking)
+			match, err := client.Matchmake(grpcClient, info.PlayerName, info.Matchmaking, 0)
+			if err != nil {
+				log.Fatalf("matchmaking failed %v", err)
+			}
+			log.Printf("joining %s with %d players and %d bots", match.Room, match.Players, match.Bots)
+			info.Password = match.Password
+			info.Team = proto.GetBackendTeam(match.Team)
+		}
+		playerID := uuid.New()
+		err = client.Connect(grpcClient, playerID, info.PlayerName, info.Password, info.Team)
+	}
 	if err != nil {
 		log.Fatalf("connect request failed %v", err)
 	}
 	client.Start()
 
+	if client.InLobby() {
+		quit := false
+		lobbyApp := lobbyApp(client, &info, lobbyStates, &quit)
+		lobbyApp.Run()
+		if quit {
+			client.Leave()
+			return
+		}
+	}
+
 	view.Start()
 
 	err = <-view.Done
+	client.Leave()
 	if err != nil {
 		log.Fatal(err)
 	}
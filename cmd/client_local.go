@@ -22,16 +22,26 @@ func main() {
 	}
 
 	numBots := flag.Int("bots", 1, "The number of bots to play against.")
+	mapPath := flag.String("map", "", "The path to a map file. Uses the default map if empty.")
 	flag.Parse()
 
+	game := backend.NewGame()
+	if *mapPath != "" {
+		gameMap, err := backend.LoadMap(*mapPath)
+		if err != nil {
+			log.Fatalf("failed to load map: %v", err)
+		}
+		game.SetMap(gameMap)
+	}
+
 	currentPlayer := backend.Player{
 		Name:            "Alice",
 		Icon:            'A',
 		IdentifierBase:  backend.IdentifierBase{uuid.New()},
-		CurrentPosition: backend.Coordinate{X: -1, Y: -5},
+		CurrentPosition: game.NextSpawnPoint(backend.TeamNone),
+		Health:          game.MaxHealth,
 	}
-	game := backend.NewGame()
-	game.AddEntity(&currentPlayer)
+	game.AddPlayer(&currentPlayer)
 
 	view := frontend.NewView(game)
 	view.CurrentPlayer = currentPlayer.ID()
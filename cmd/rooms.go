@@ -0,0 +1,83 @@
+package main
+
+// Lists or creates rooms on a server, or shows its leaderboard.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mortenson/grpc-game-example/proto"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	address := flag.String("address", ":8888", "The server address.")
+	name := flag.String("name", "", "The name of the room to create.")
+	password := flag.String("password", "", "The password for the new room.")
+	mode := flag.String("mode", "", "The game mode of the new room.")
+	mapName := flag.String("map", "", "The name of a server map for the new room. Rotates through all server maps if empty.")
+	numBots := flag.Int("bots", 0, "The number of bots to add to the new room.")
+	lobby := flag.Bool("lobby", false, "Make players wait in a lobby until the match starts.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] list|create|leaderboard\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("can not connect with server %v", err)
+	}
+	grpcClient := proto.NewGameClient(conn)
+
+	switch flag.Arg(0) {
+	case "list":
+		resp, err := grpcClient.ListRooms(context.Background(), &proto.ListRoomsRequest{})
+		if err != nil {
+			log.Fatalf("list request failed %v", err)
+		}
+		for _, room := range resp.Rooms {
+			details := []string{
+				room.Mode,
+				room.Map,
+				fmt.Sprintf("%d/%d players", room.Players, room.MaxPlayers),
+				fmt.Sprintf("%d spectators", room.Spectators),
+			}
+			if room.HasPassword {
+				details = append(details, "password")
+			}
+			if room.InLobby {
+				details = append(details, "in lobby")
+			}
+			fmt.Printf("%s (%s)\n", room.Name, strings.Join(details, ", "))
+		}
+	case "create":
+		room, err := grpcClient.CreateRoom(context.Background(), &proto.CreateRoomRequest{
+			Name:     *name,
+			Password: *password,
+			Mode:     *mode,
+			Map:      *mapName,
+			Bots:     int32(*numBots),
+			Lobby:    *lobby,
+		})
+		if err != nil {
+			log.Fatalf("create request failed %v", err)
+		}
+		fmt.Printf("created %s, join it within a minute or it will be removed\n", room.Name)
+	case "leaderboard":
+		resp, err := grpcClient.Leaderboard(context.Background(), &proto.LeaderboardRequest{})
+		if err != nil {
+			log.Fatalf("leaderboard request failed %v", err)
+		}
+		for _, entry := range resp.Entries {
+			fmt.Printf("%d. %s - %d (%d wins in %d rounds)\n", entry.Rank, entry.Name, entry.Rating, entry.Wins, entry.Rounds)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
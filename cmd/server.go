@@ -7,9 +7,10 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strings"
 
 	"github.com/mortenson/grpc-game-example/pkg/backend"
-	"github.com/mortenson/grpc-game-example/pkg/bot"
+	"github.com/mortenson/grpc-game-example/pkg/rating"
 	"github.com/mortenson/grpc-game-example/pkg/server"
 	"github.com/mortenson/grpc-game-example/proto"
 
@@ -18,29 +19,79 @@ import (
 
 func main() {
 	port := flag.Int("port", 8888, "The port to listen on.")
-	password := flag.String("password", "", "The server password.")
-	numBots := flag.Int("bots", 0, "The number of bots to add to the server.")
+	password := flag.String("password", "", "The password for the default room.")
+	numBots := flag.Int("bots", 0, "The number of bots to add to the default room.")
+	mapPaths := flag.String("map", "", "A comma separated list of map files to rotate through each round. Rooms created by clients can pick from these maps. Uses the default map if empty.")
+	shuffleMaps := flag.Bool("shuffle", false, "Shuffle the map rotation.")
+	maxHealth := flag.Int("health", backend.DefaultMaxHealth, "The number of laser hits a player can take before dying.")
+	mode := flag.String("mode", "deathmatch", fmt.Sprintf("The game mode of the default room, one of: %s.", strings.Join(backend.GameModeNames(), ", ")))
+	friendlyFire := flag.Bool("friendlyfire", false, "Allow players to damage their teammates.")
+	tickRate := flag.Int("tickrate", backend.DefaultTickRate, "The number of game ticks per second.")
+	maxRewind := flag.Duration("maxrewind", backend.DefaultMaxRewind, "How far back in time laser hits are checked to make up for player latency.")
+	lobby := flag.Bool("lobby", false, "Make players in the default room wait in a lobby until the match starts.")
+	ratingsPath := flag.String("ratings", "", "A file to keep player ratings in, so that the ladder is kept when the server restarts. Ratings are only kept in memory if empty.")
 	flag.Parse()
 
+	maps := make([]*backend.Map, 0)
+	if *mapPaths != "" {
+		for _, mapPath := range strings.Split(*mapPaths, ",") {
+			gameMap, err := backend.LoadMap(mapPath)
+			if err != nil {
+				log.Fatalf("failed to load map: %v", err)
+			}
+			maps = append(maps, gameMap)
+		}
+	}
+	newMode, ok := backend.GameModes[*mode]
+	if !ok {
+		log.Fatalf("unknown game mode %q", *mode)
+	}
+	modeMaps := maps
+	if len(modeMaps) == 0 {
+		modeMaps = []*backend.Map{backend.MapDefault}
+	}
+	if err := backend.CheckMaps(newMode(), modeMaps); err != nil {
+		log.Fatalf("invalid game mode: %v", err)
+	}
+
 	log.Printf("listening on port %d", *port)
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	game := backend.NewGame()
-
-	bots := bot.NewBots(game)
-	for i := 0; i < *numBots; i++ {
-		bots.AddBot(fmt.Sprintf("Bob %d", i))
+	// Settings that apply to every room.
+	newGame := func() *backend.Game {
+		game := backend.NewGame()
+		game.TickRate = *tickRate
+		game.MaxHealth = *maxHealth
+		game.MaxRewind = *maxRewind
+		game.FriendlyFire = *friendlyFire
+		return game
+	}
+	rooms := server.NewRoomManager(newGame, maps)
+	if *ratingsPath != "" {
+		rooms.Ratings, err = rating.LoadRatings(*ratingsPath)
+		if err != nil {
+			log.Fatalf("failed to load ratings: %v", err)
+		}
+	}
+	err = rooms.AddRoom(server.RoomOptions{
+		Name:       server.DefaultRoom,
+		Password:   *password,
+		Mode:       *mode,
+		Maps:       maps,
+		Shuffle:    *shuffleMaps,
+		Bots:       *numBots,
+		Persistent: true,
+		Lobby:      *lobby,
+	})
+	if err != nil {
+		log.Fatalf("failed to create the default room: %v", err)
 	}
-
-	game.Start()
-	bots.Start()
 
 	s := grpc.NewServer()
-	server := server.NewGameServer(game, *password)
-	proto.RegisterGameServer(s, server)
+	proto.RegisterGameServer(s, rooms)
 
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
@@ -10,29 +10,55 @@ import (
 )
 
 const (
-	roundOverScore          = 10
-	newRoundWaitTime        = 10 * time.Second
-	collisionCheckFrequency = 10 * time.Millisecond
-	moveThrottle            = 100 * time.Millisecond
-	laserThrottle           = 500 * time.Millisecond
-	laserSpeed              = 50
+	newRoundWaitTime     = 10 * time.Second
+	moveThrottle         = 100 * time.Millisecond
+	switchWeaponThrottle = 100 * time.Millisecond
+	// DefaultMaxHealth is the health players start with when MaxHealth is
+	// not set.
+	DefaultMaxHealth = 3
+	// DefaultTickRate is the number of ticks per second used when TickRate
+	// is not set.
+	DefaultTickRate = 60
+	// DefaultMaxRewind is how far back in time laser hits are checked to
+	// make up for the shooter's latency.
+	DefaultMaxRewind = 200 * time.Millisecond
 )
 
 // Game is the backend engine for the game. It can be used regardless of how
 // game data is rendered, or if a game server is being used.
 type Game struct {
 	Entities        map[uuid.UUID]Identifier
-	gameMap         [][]rune
+	gameMap         *Map
+	MapRotation     *MapRotation
 	Mu              sync.RWMutex
-	ChangeChannel   chan Change
+	ChangeBus       *ChangeBus
 	ActionChannel   chan Action
 	lastAction      map[string]time.Time
 	Score           map[uuid.UUID]int
 	NewRoundAt      time.Time
 	RoundWinner     uuid.UUID
+	RoundWinnerTeam Team
+	RoundPlacements [][]uuid.UUID
 	WaitForRound    bool
 	IsAuthoritative bool
 	spawnPointIndex int
+	TickRate        int
+	Tick            uint64
+	Clock           Clock
+	MaxHealth       int
+	MaxRewind       time.Duration
+	Mode            GameMode
+	FriendlyFire    bool
+	Hill            *Rectangle
+	HillMovesAt     time.Time
+	actionQueue     []Action
+	actionMu        sync.Mutex
+	pendingChanges  []Change
+	pickupSpawners  []*pickupSpawner
+	pickupMap       *Map
+	positionHistory []positionSnapshot
+	done            chan struct{}
+	stopOnce        sync.Once
 }
 
 // NewGame constructs a new Game struct.
@@ -41,120 +67,232 @@ func NewGame() *Game {
 		Entities:        make(map[uuid.UUID]Identifier),
 		ActionChannel:   make(chan Action, 1),
 		lastAction:      make(map[string]time.Time),
-		ChangeChannel:   make(chan Change, 1),
+		ChangeBus:       NewChangeBus(),
 		IsAuthoritative: true,
 		WaitForRound:    false,
 		Score:           make(map[uuid.UUID]int),
 		gameMap:         MapDefault,
 		spawnPointIndex: 0,
+		TickRate:        DefaultTickRate,
+		Clock:           RealClock{},
+		MaxHealth:       DefaultMaxHealth,
+		MaxRewind:       DefaultMaxRewind,
+		Mode:            &DeathmatchMode{},
+		done:            make(chan struct{}),
 	}
 	return &game
 }
 
-// Start begins the main game loop, which waits for new actions and updates the
-// game state occordinly.
+// Start begins the main game loop, which steps the game state at a fixed rate
+// and performs actions as they are queued.
 func (game *Game) Start() {
 	go game.watchActions()
-	go game.watchCollisions()
+	go game.watchTicks()
 }
 
-// watchActions waits for new actions to come in and performs them.
+// Stop ends the main game loop. Actions sent after the game has stopped are
+// never performed.
+func (game *Game) Stop() {
+	game.stopOnce.Do(func() {
+		close(game.done)
+	})
+}
+
+// watchActions waits for new actions to come in and queues them to be
+// performed on the next tick.
 func (game *Game) watchActions() {
 	for {
-		action := <-game.ActionChannel
-		if game.WaitForRound {
-			continue
+		select {
+		case action := <-game.ActionChannel:
+			game.actionMu.Lock()
+			game.actionQueue = append(game.actionQueue, action)
+			game.actionMu.Unlock()
+		case <-game.done:
+			return
 		}
-		game.Mu.Lock()
-		action.Perform(game)
-		game.Mu.Unlock()
 	}
 }
 
-// watchCollisions checks for entity collisions - al we care about now is when
-// a laser and a player collide but this could probably be more generalized.
-func (game *Game) watchCollisions() {
+// watchTicks steps the game TickRate times per second.
+func (game *Game) watchTicks() {
+	tickRate := game.TickRate
+	if tickRate <= 0 {
+		tickRate = DefaultTickRate
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(tickRate))
+	defer ticker.Stop()
 	for {
-		game.Mu.Lock()
-		spawnPoints := game.GetMapByType()[MapTypeSpawn]
-		for _, entities := range game.getCollisionMap() {
-			if len(entities) <= 1 {
-				continue
+		select {
+		case <-ticker.C:
+			game.Step()
+		case <-game.done:
+			return
+		}
+	}
+}
+
+// Step advances the game by one tick. Queued actions are performed in the
+// order they were received, collisions are resolved, and then all changes
+// made during the tick are sent. Start calls this automatically, but it can
+// also be called directly to drive the game manually.
+func (game *Game) Step() {
+	game.actionMu.Lock()
+	actions := game.actionQueue
+	game.actionQueue = nil
+	game.actionMu.Unlock()
+
+	game.Mu.Lock()
+	game.Tick++
+	for _, action := range actions {
+		if !game.WaitForRound {
+			action.Perform(game)
+		}
+		// Acknowledge client inputs, even if they were ignored.
+		input, ok := action.(InputAction)
+		if ok {
+			game.acknowledgeInput(input)
+		}
+	}
+	game.checkCollisions()
+	// Only authoritative games run game mode logic, decide when rounds start
+	// and end, manage pickups, and record positions to rewind laser hits.
+	if game.IsAuthoritative {
+		if game.WaitForRound && !game.Clock.Now().Before(game.NewRoundAt) {
+			game.startNewRound()
+		}
+		game.checkPickups()
+		game.updatePickups()
+		if !game.WaitForRound {
+			game.Mode.Tick(game)
+			winnerID, winnerTeam, over := game.Mode.CheckWinCondition(game)
+			if over {
+				game.RoundWinnerTeam = winnerTeam
+				game.queueNewRound(winnerID)
 			}
-			// Get the first laser, if present.
-			hasLaser := false
-			var laserOwnerID uuid.UUID
-			for _, entity := range entities {
-				laser, ok := entity.(*Laser)
-				if ok {
-					hasLaser = true
-					laserOwnerID = laser.OwnerID
-					break
-				}
+		}
+		game.recordPositions()
+	}
+	changes := game.pendingChanges
+	game.pendingChanges = nil
+	tick := game.Tick
+	game.Mu.Unlock()
+
+	if len(changes) > 0 {
+		game.ChangeBus.Publish(TickChange{
+			Tick: tick,
+		})
+	}
+	for _, change := range changes {
+		game.ChangeBus.Publish(change)
+	}
+}
+
+// checkCollisions checks for entity collisions - al we care about now is when
+// a laser and a player collide but this could probably be more generalized.
+// Lasers can move more than one tile per tick, so every tile a laser moved
+// through since the last tick is checked, in order.
+func (game *Game) checkCollisions() {
+	collisionMap := game.getCollisionMap()
+	walls := make(map[Coordinate]bool)
+	for _, wall := range game.GetMapByType()[MapTypeWall] {
+		walls[wall] = true
+	}
+	for _, entity := range game.Entities {
+		laser, ok := entity.(*Laser)
+		if !ok {
+			continue
+		}
+		weaponRange := Weapons[laser.Weapon].Range
+		moves := laser.moves()
+		for move := laser.nextMove; move <= moves; move++ {
+			laser.nextMove = move + 1
+			// Remove lasers that have travelled past their weapon's range.
+			if weaponRange != 0 && move > weaponRange {
+				game.removeLaser(laser)
+				break
 			}
-			if !hasLaser {
-				continue
+			position := laser.positionAfter(move)
+			if game.checkLaserCollision(laser, position, collisionMap) {
+				break
 			}
-			// Handle entities that collided with the laser.
-			for _, entity := range entities {
-				switch entity.(type) {
-				case *Player:
-					// If the game isn't authoritative, another system decides
-					// when players die and score is changed.
-					if !game.IsAuthoritative {
-						continue
-					}
-					player := entity.(*Player)
-					// Don't allow players to kill themselves.
-					if player.ID() == laserOwnerID {
-						continue
-					}
-					// Choose the next spawn point.
-					spawnPoint := spawnPoints[game.spawnPointIndex%len(spawnPoints)]
-					game.spawnPointIndex++
-					player.Move(spawnPoint)
-					change := PlayerRespawnChange{
-						Player:     player,
-						KilledByID: laserOwnerID,
-					}
-					game.sendChange(change)
-					game.AddScore(laserOwnerID)
-					if game.Score[laserOwnerID] >= roundOverScore {
-						game.queueNewRound(laserOwnerID)
-					}
-				case *Laser:
-					change := RemoveEntityChange{
-						Entity: entity,
-					}
-					game.sendChange(change)
-					game.RemoveEntity(entity.ID())
-				}
+			// Remove lasers that hit walls.
+			if walls[position] {
+				game.removeLaser(laser)
+				break
 			}
 		}
-		// Remove lasers that hit walls.
-		collisionMap := game.getCollisionMap()
-		for _, wall := range game.GetMapByType()[MapTypeWall] {
-			entities, ok := collisionMap[wall]
-			if !ok {
+	}
+}
+
+// removeLaser removes a laser from the game.
+func (game *Game) removeLaser(laser *Laser) {
+	change := RemoveEntityChange{
+		Entity: laser,
+	}
+	game.sendChange(change)
+	game.RemoveEntity(laser.ID())
+}
+
+// checkLaserCollision handles a laser colliding with entities at a position
+// it moved through, and determines if the laser was stopped.
+func (game *Game) checkLaserCollision(laser *Laser, position Coordinate, collisionMap map[Coordinate][]Identifier) bool {
+	// Players are checked where the shooter saw them when the laser was
+	// fired, other lasers are checked where they are now. Lasers pass over
+	// everything else, like pickups and flags.
+	entities := game.playersAt(position, laser.Rewind)
+	for _, other := range collisionMap[position] {
+		_, isLaser := other.(*Laser)
+		if isLaser && other.ID() != laser.ID() && game.GetEntity(other.ID()) != nil {
+			entities = append(entities, other)
+		}
+	}
+	if len(entities) == 0 {
+		return false
+	}
+	laserOwnerID := laser.OwnerID
+	laserWeapon := Weapons[laser.Weapon]
+	entities = append(entities, laser)
+	// Handle entities that collided with the laser.
+	for _, entity := range entities {
+		switch entity.(type) {
+		case *Player:
+			// If the game isn't authoritative, another system decides
+			// when players die and score is changed.
+			if !game.IsAuthoritative {
+				continue
+			}
+			player := entity.(*Player)
+			// Don't allow players to kill themselves.
+			if player.ID() == laserOwnerID {
 				continue
 			}
-			for _, entity := range entities {
-				switch entity.(type) {
-				case *Laser:
-					change := RemoveEntityChange{
-						Entity: entity,
-					}
-					game.sendChange(change)
-					game.RemoveEntity(entity.ID())
+			if game.isFriendlyFire(laserOwnerID, player) {
+				continue
+			}
+			// Shields block all damage.
+			if player.HasEffect(PickupShield, game.Clock.Now()) {
+				continue
+			}
+			player.Health -= laserWeapon.Damage
+			if player.Health > 0 {
+				change := PlayerDamageChange{
+					Player:      player,
+					Health:      player.Health,
+					DamagedByID: laserOwnerID,
 				}
+				game.sendChange(change)
+				continue
 			}
+			game.Mode.PlayerKilled(game, player, laserOwnerID)
+		case *Laser:
+			game.removeLaser(entity.(*Laser))
 		}
-		game.Mu.Unlock()
-		time.Sleep(collisionCheckFrequency)
 	}
+	return true
 }
 
-// getCollisionMap maps coordinates to sets of entities.
+// getCollisionMap maps coordinates to sets of entities. Dead players are
+// spectating, so they are left out.
 func (game *Game) getCollisionMap() map[Coordinate][]Identifier {
 	collisionMap := map[Coordinate][]Identifier{}
 	for _, entity := range game.Entities {
@@ -162,6 +300,10 @@ func (game *Game) getCollisionMap() map[Coordinate][]Identifier {
 		if !ok {
 			continue
 		}
+		player, ok := entity.(*Player)
+		if ok && !player.IsAlive() {
+			continue
+		}
 		position := positioner.Position()
 		collisionMap[position] = append(collisionMap[position], entity)
 	}
@@ -170,14 +312,24 @@ func (game *Game) getCollisionMap() map[Coordinate][]Identifier {
 
 // AddEntity adds an entity to the game.
 func (game *Game) AddEntity(entity Identifier) {
+	game.setEntityClock(entity)
 	game.Entities[entity.ID()] = entity
 }
 
 // UpdateEntity updates an entity.
 func (game *Game) UpdateEntity(entity Identifier) {
+	game.setEntityClock(entity)
 	game.Entities[entity.ID()] = entity
 }
 
+// setEntityClock shares the game clock with entities that depend on time.
+func (game *Game) setEntityClock(entity Identifier) {
+	laser, ok := entity.(*Laser)
+	if ok {
+		laser.clock = game.Clock
+	}
+}
+
 // GetEntity gets an entity from the game.
 func (game *Game) GetEntity(id uuid.UUID) Identifier {
 	return game.Entities[id]
@@ -188,41 +340,77 @@ func (game *Game) RemoveEntity(id uuid.UUID) {
 	delete(game.Entities, id)
 }
 
-// startNewRound resets the game state in order to
-// start a new round.
+// startNewRound moves to the next map in the rotation, if any, and starts a
+// new round.
 func (game *Game) startNewRound() {
+	if game.MapRotation != nil {
+		gameMap := game.MapRotation.Next()
+		if gameMap != game.gameMap {
+			game.changeMap(gameMap)
+		}
+	}
+	game.StartRound()
+}
+
+// StartRound starts a round on the current map right away, resetting scores
+// and players. This is used to start a match after players have waited in a
+// lobby. The game lock must be held by the caller.
+func (game *Game) StartRound() {
 	game.WaitForRound = false
 	game.Score = map[uuid.UUID]int{}
-	i := 0
-	spawnPoints := game.GetMapByType()[MapTypeSpawn]
+	game.RoundWinnerTeam = TeamNone
 	for _, entity := range game.Entities {
 		player, ok := entity.(*Player)
 		if !ok {
 			continue
 		}
-		player.Move(spawnPoints[i%len(spawnPoints)])
-		i++
+		player.Move(game.NextSpawnPoint(player.Team))
+		player.Health = game.MaxHealth
+		player.Effects = nil
+		player.State = PlayerAlive
 	}
+	game.Mode.RoundStarted(game)
 	game.sendChange(RoundStartChange{})
 }
 
-// queueNewRound queues a new round to start.
+// changeMap switches to a new map between rounds. Lasers from the old map are
+// removed, and players are expected to be moved to the new spawn points.
+// Pickups are replaced by updatePickups.
+func (game *Game) changeMap(gameMap *Map) {
+	for _, entity := range game.Entities {
+		laser, ok := entity.(*Laser)
+		if !ok {
+			continue
+		}
+		game.sendChange(RemoveEntityChange{
+			Entity: laser,
+		})
+		game.RemoveEntity(laser.ID())
+	}
+	game.SetMap(gameMap)
+	game.sendChange(MapChange{
+		Map: gameMap,
+	})
+}
+
+// queueNewRound queues a new round to start. The round is started by Step
+// once NewRoundAt has passed.
 func (game *Game) queueNewRound(roundWinner uuid.UUID) {
 	game.WaitForRound = true
-	game.NewRoundAt = time.Now().Add(newRoundWaitTime)
+	game.NewRoundAt = game.Clock.Now().Add(newRoundWaitTime)
 	game.RoundWinner = roundWinner
+	game.RoundPlacements = game.Mode.Placements(game)
 	game.sendChange(RoundOverChange{})
-	go func() {
-		time.Sleep(newRoundWaitTime)
-		game.Mu.Lock()
-		game.startNewRound()
-		game.Mu.Unlock()
-	}()
 }
 
 // AddScore increments an entity's score.
 func (game *Game) AddScore(id uuid.UUID) {
 	game.Score[id]++
+	change := ScoreChange{
+		PlayerID: id,
+		Score:    game.Score[id],
+	}
+	game.sendChange(change)
 }
 
 // checkLastActionTime checks the last time an action was performed.
@@ -240,12 +428,9 @@ func (game *Game) updateLastActionTime(actionKey string, created time.Time) {
 	game.lastAction[actionKey] = created
 }
 
-// sendChange sends a change to the change channel.
+// sendChange queues a change to be sent at the end of the current tick.
 func (game *Game) sendChange(change Change) {
-	select {
-	case game.ChangeChannel <- change:
-	default:
-	}
+	game.pendingChanges = append(game.pendingChanges, change)
 }
 
 // Coordinate is used for all position-related variables.
@@ -262,6 +447,14 @@ func (c1 Coordinate) Add(c2 Coordinate) Coordinate {
 	}
 }
 
+// Sub subtracts a coordinate from another.
+func (c1 Coordinate) Sub(c2 Coordinate) Coordinate {
+	return Coordinate{
+		X: c1.X - c2.X,
+		Y: c1.Y - c2.Y,
+	}
+}
+
 // Distance calculates the distance between two coordinates.
 func (c1 Coordinate) Distance(c2 Coordinate) int {
 	return int(math.Sqrt(math.Pow(float64(c2.X-c1.X), 2) + math.Pow(float64(c2.Y-c1.Y), 2)))
@@ -307,6 +500,22 @@ func (e IdentifierBase) ID() uuid.UUID {
 // Change is sent by the game engine in response to Actions.
 type Change interface{}
 
+// TickChange is sent before the changes made during a tick.
+type TickChange struct {
+	Change
+	Tick uint64
+}
+
+// InputAckChange is sent when an input from a client has been processed.
+// Position is where the player was after the input, which lets the client
+// correct its prediction.
+type InputAckChange struct {
+	Change
+	PlayerID uuid.UUID
+	Sequence uint64
+	Position Coordinate
+}
+
 // MoveChange is sent when the game engine moves an entity.
 type MoveChange struct {
 	Change
@@ -326,6 +535,12 @@ type RoundStartChange struct {
 	Change
 }
 
+// MapChange indicates that the map has changed between rounds.
+type MapChange struct {
+	Change
+	Map *Map
+}
+
 // AddEntityChange occurs when an entity is added in response to an action.
 // Currently this is only used for new lasers and players joining the game.
 type AddEntityChange struct {
@@ -339,6 +554,28 @@ type RemoveEntityChange struct {
 	Entity Identifier
 }
 
+// WeaponChange occurs when a player switches weapons.
+type WeaponChange struct {
+	Change
+	Player *Player
+	Weapon WeaponType
+}
+
+// PickupChange occurs when a player collects a pickup.
+type PickupChange struct {
+	Change
+	Player *Player
+	Pickup *Pickup
+}
+
+// PlayerDamageChange occurs when a player has been hit but not killed.
+type PlayerDamageChange struct {
+	Change
+	Player      *Player
+	Health      int
+	DamagedByID uuid.UUID
+}
+
 // PlayerRespawnChange occurs when a player has been killed and is respawning.
 type PlayerRespawnChange struct {
 	Change
@@ -346,6 +583,56 @@ type PlayerRespawnChange struct {
 	KilledByID uuid.UUID
 }
 
+// PlayerDeathChange occurs when a player has been killed and is spectating
+// until the round is over.
+type PlayerDeathChange struct {
+	Change
+	Player     *Player
+	KilledByID uuid.UUID
+}
+
+// ScoreChange occurs when a player's score changes.
+type ScoreChange struct {
+	Change
+	PlayerID uuid.UUID
+	Score    int
+}
+
+// HillChange occurs when the king of the hill zone moves.
+type HillChange struct {
+	Change
+	Hill    *Rectangle
+	MovesAt time.Time
+}
+
+// FlagPickupChange occurs when a player picks up the enemy flag.
+type FlagPickupChange struct {
+	Change
+	Flag   *Flag
+	Player *Player
+}
+
+// FlagDropChange occurs when a flag carrier is killed or leaves the game.
+type FlagDropChange struct {
+	Change
+	Flag *Flag
+}
+
+// FlagReturnChange occurs when a player returns their team's dropped flag to
+// its base.
+type FlagReturnChange struct {
+	Change
+	Flag   *Flag
+	Player *Player
+}
+
+// FlagCaptureChange occurs when a player brings the enemy flag to their base.
+type FlagCaptureChange struct {
+	Change
+	Flag   *Flag
+	Player *Player
+}
+
 // Action is sent by the client when attempting to change game state. The
 // engine can choose to reject Actions if they are invalid or performed too
 // frequently.
@@ -353,34 +640,32 @@ type Action interface {
 	Perform(game *Game)
 }
 
-// MoveAction is sent when a user presses an arrow key.
-type MoveAction struct {
-	Direction Direction
-	ID        uuid.UUID
-	Created   time.Time
+// InputAction wraps an action sent by a remote client, so that it can be
+// acknowledged once it has been processed.
+type InputAction struct {
+	Action
+	PlayerID uuid.UUID
+	Sequence uint64
 }
 
-// Perform contains backend logic required to move an entity.
-func (action MoveAction) Perform(game *Game) {
-	entity := game.GetEntity(action.ID)
-	if entity == nil {
-		return
-	}
-	mover, ok := entity.(Mover)
+// acknowledgeInput tells the client that sent an input where their player is
+// now that the input has been processed.
+func (game *Game) acknowledgeInput(input InputAction) {
+	player, ok := game.GetEntity(input.PlayerID).(*Player)
 	if !ok {
 		return
 	}
-	positioner, ok := entity.(Positioner)
-	if !ok {
-		return
-	}
-	actionKey := fmt.Sprintf("%T:%s", action, entity.ID().String())
-	if !game.checkLastActionTime(actionKey, action.Created, moveThrottle) {
-		return
-	}
-	position := positioner.Position()
-	// Move the entity.
-	switch action.Direction {
+	game.sendChange(InputAckChange{
+		PlayerID: input.PlayerID,
+		Sequence: input.Sequence,
+		Position: player.Position(),
+	})
+}
+
+// NextPosition determines where an entity moving in a direction would end up,
+// and if the move is possible, i.e. not blocked by a wall or a player.
+func (game *Game) NextPosition(position Coordinate, direction Direction) (Coordinate, bool) {
+	switch direction {
 	case DirectionUp:
 		position.Y--
 	case DirectionDown:
@@ -393,7 +678,7 @@ func (action MoveAction) Perform(game *Game) {
 	// Check if position collides with a wall.
 	for _, wall := range game.GetMapByType()[MapTypeWall] {
 		if position == wall {
-			return
+			return position, false
 		}
 	}
 	// Check if position collides with a player.
@@ -402,10 +687,51 @@ func (action MoveAction) Perform(game *Game) {
 		for _, entity := range collidingEntities {
 			_, ok := entity.(*Player)
 			if ok {
-				return
+				return position, false
 			}
 		}
 	}
+	return position, true
+}
+
+// MoveAction is sent when a user presses an arrow key.
+type MoveAction struct {
+	Direction Direction
+	ID        uuid.UUID
+	Created   time.Time
+}
+
+// Perform contains backend logic required to move an entity.
+func (action MoveAction) Perform(game *Game) {
+	entity := game.GetEntity(action.ID)
+	if entity == nil {
+		return
+	}
+	mover, ok := entity.(Mover)
+	if !ok {
+		return
+	}
+	positioner, ok := entity.(Positioner)
+	if !ok {
+		return
+	}
+	actionKey := fmt.Sprintf("%T:%s", action, entity.ID().String())
+	throttle := moveThrottle
+	player, ok := entity.(*Player)
+	if ok && !player.IsAlive() {
+		return
+	}
+	if ok && player.HasEffect(PickupSpeedBoost, game.Clock.Now()) {
+		throttle /= 2
+	}
+	if !game.checkLastActionTime(actionKey, action.Created, throttle) {
+		return
+	}
+	position, ok := game.NextPosition(positioner.Position(), action.Direction)
+	if !ok {
+		return
+	}
+	// Move the entity.
 	mover.Move(position)
 	// Inform the client that the entity moved.
 	change := MoveChange{
@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// recordAction records the order and tick it was performed on.
+type recordAction struct {
+	name      string
+	performed *[]string
+}
+
+// Perform records the action.
+func (action recordAction) Perform(game *Game) {
+	*action.performed = append(*action.performed, fmt.Sprintf("%s@%d", action.name, game.Tick))
+}
+
+func TestStepPerformsActionsInOrder(t *testing.T) {
+	game := NewGame()
+	performed := make([]string, 0)
+	for _, name := range []string{"a", "b", "c"} {
+		game.actionQueue = append(game.actionQueue, recordAction{name: name, performed: &performed})
+	}
+	game.Step()
+	game.actionQueue = append(game.actionQueue, recordAction{name: "d", performed: &performed})
+	game.Step()
+	game.Step()
+
+	expected := fmt.Sprint([]string{"a@1", "b@1", "c@1", "d@2"})
+	if fmt.Sprint(performed) != expected {
+		t.Errorf("expected actions %s, got %v", expected, performed)
+	}
+	if game.Tick != 3 {
+		t.Errorf("expected tick 3, got %d", game.Tick)
+	}
+}
+
+func TestStepSendsTickBeforeChanges(t *testing.T) {
+	game, _ := newTestGame(t, openGrid)
+	player := addTestPlayer(game, "player", Coordinate{X: 0, Y: 0})
+	subscription := game.ChangeBus.Subscribe(10, OverflowBlock)
+	defer subscription.Unsubscribe()
+
+	// Ticks without changes are not sent.
+	game.Step()
+	game.actionQueue = append(game.actionQueue, MoveAction{
+		ID:        player.ID(),
+		Direction: DirectionRight,
+		Created:   game.Clock.Now(),
+	})
+	if len(subscription.Changes) != 0 {
+		t.Fatalf("expected no changes before the tick")
+	}
+	game.Step()
+
+	tickChange, ok := (<-subscription.Changes).(TickChange)
+	if !ok || tickChange.Tick != 2 {
+		t.Fatalf("expected a tick change for tick 2, got %+v", tickChange)
+	}
+	change, ok := (<-subscription.Changes).(MoveChange)
+	if !ok || change.Position != (Coordinate{X: 1, Y: 0}) {
+		t.Errorf("expected a move change to 1,0, got %+v", change)
+	}
+}
+
+func TestDeadPlayersDoNotBlockMovement(t *testing.T) {
+	game, _ := newTestGame(t, openGrid)
+	player := addTestPlayer(game, "player", Coordinate{X: 0, Y: 0})
+	dead := addTestPlayer(game, "dead", Coordinate{X: 1, Y: 0})
+
+	if _, ok := game.NextPosition(player.Position(), DirectionRight); ok {
+		t.Fatalf("expected a living player to block movement")
+	}
+	dead.State = PlayerDead
+	if _, ok := game.NextPosition(player.Position(), DirectionRight); !ok {
+		t.Errorf("expected a dead player not to block movement")
+	}
+}
+
+func TestLasersPassThroughDeadPlayers(t *testing.T) {
+	game, clock := newTestGame(t, openGrid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -6, Y: 0})
+	dead := addTestPlayer(game, "dead", Coordinate{X: 0, Y: 0})
+	dead.State = PlayerDead
+	target := addTestPlayer(game, "target", Coordinate{X: 6, Y: 0})
+
+	fire(game, shooter, DirectionRight)
+	tick := time.Second / DefaultTickRate
+	for i := 0; i < DefaultTickRate*2; i++ {
+		game.Step()
+		clock.Advance(tick)
+	}
+	if dead.Health != game.MaxHealth {
+		t.Errorf("expected the dead player not to be damaged")
+	}
+	expected := game.MaxHealth - Weapons[WeaponLaser].Damage
+	if target.Health != expected {
+		t.Errorf("expected target health to be %d, got %d", expected, target.Health)
+	}
+}
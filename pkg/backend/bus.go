@@ -0,0 +1,145 @@
+package backend
+
+import "sync"
+
+// OverflowPolicy decides what a ChangeBus does when a subscriber's queue is
+// full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the publisher wait until the subscriber has room
+	// in its queue. No changes are lost, but a slow subscriber slows down
+	// the game.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDisconnect unsubscribes the subscriber, which closes its
+	// channel. The subscriber never receives a partial set of changes.
+	OverflowDisconnect
+)
+
+// Subscription receives every change published to a ChangeBus, in the order
+// they were published.
+type Subscription struct {
+	Changes   <-chan Change
+	changes   chan Change
+	policy    OverflowPolicy
+	done      chan struct{}
+	closeOnce sync.Once
+	// mu guards sending on and closing changes, so that a publisher waiting
+	// on a full queue never holds the bus mutex.
+	mu     sync.Mutex
+	closed bool
+	bus    *ChangeBus
+}
+
+// Unsubscribe stops delivery of changes and closes the Changes channel.
+func (subscription *Subscription) Unsubscribe() {
+	subscription.closeOnce.Do(func() {
+		close(subscription.done)
+	})
+	subscription.bus.remove(subscription)
+}
+
+// send queues a change for the subscriber. If the queue is full and wait is
+// false, it returns false without queueing the change. If wait is true, it
+// blocks until there is room or the subscriber unsubscribes.
+func (subscription *Subscription) send(change Change, wait bool) bool {
+	subscription.mu.Lock()
+	defer subscription.mu.Unlock()
+	if subscription.closed {
+		return true
+	}
+	select {
+	case subscription.changes <- change:
+		return true
+	default:
+	}
+	if !wait {
+		return false
+	}
+	select {
+	case subscription.changes <- change:
+	case <-subscription.done:
+	}
+	return true
+}
+
+// close closes the Changes channel once.
+func (subscription *Subscription) close() {
+	subscription.mu.Lock()
+	defer subscription.mu.Unlock()
+	if subscription.closed {
+		return
+	}
+	subscription.closed = true
+	close(subscription.changes)
+}
+
+// ChangeBus delivers changes from the game engine to any number of
+// subscribers, each with its own bounded queue.
+type ChangeBus struct {
+	mu            sync.Mutex
+	publishMu     sync.Mutex
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewChangeBus constructs a new ChangeBus struct.
+func NewChangeBus() *ChangeBus {
+	return &ChangeBus{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe adds a new subscriber with a queue of the given size.
+func (bus *ChangeBus) Subscribe(size int, policy OverflowPolicy) *Subscription {
+	changes := make(chan Change, size)
+	subscription := &Subscription{
+		Changes: changes,
+		changes: changes,
+		policy:  policy,
+		done:    make(chan struct{}),
+		bus:     bus,
+	}
+	bus.mu.Lock()
+	bus.subscriptions[subscription] = struct{}{}
+	bus.mu.Unlock()
+	return subscription
+}
+
+// Publish sends a change to every subscriber. Calls to Publish are serialized
+// so every subscriber sees changes in the same order. Subscribers with room
+// in their queue receive the change first, then Publish waits for any full
+// OverflowBlock subscribers without holding the bus mutex, so they do not
+// stall Subscribe or Unsubscribe.
+func (bus *ChangeBus) Publish(change Change) {
+	bus.publishMu.Lock()
+	defer bus.publishMu.Unlock()
+	bus.mu.Lock()
+	subscriptions := make([]*Subscription, 0, len(bus.subscriptions))
+	for subscription := range bus.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	bus.mu.Unlock()
+	full := make([]*Subscription, 0)
+	for _, subscription := range subscriptions {
+		if subscription.send(change, false) {
+			continue
+		}
+		switch subscription.policy {
+		case OverflowBlock:
+			full = append(full, subscription)
+		case OverflowDisconnect:
+			bus.remove(subscription)
+		}
+	}
+	for _, subscription := range full {
+		subscription.send(change, true)
+	}
+}
+
+// remove deletes a subscription and closes its channel.
+func (bus *ChangeBus) remove(subscription *Subscription) {
+	bus.mu.Lock()
+	delete(bus.subscriptions, subscription)
+	bus.mu.Unlock()
+	subscription.close()
+}
@@ -0,0 +1,111 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+// waitTimeout is how long bus tests wait before deciding a call is blocked.
+const waitTimeout = 50 * time.Millisecond
+
+// publishAsync publishes a change in a goroutine, closing the returned
+// channel once Publish returns.
+func publishAsync(bus *ChangeBus, change Change) chan struct{} {
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(change)
+		close(published)
+	}()
+	return published
+}
+
+func TestChangeBusDeliversInOrder(t *testing.T) {
+	bus := NewChangeBus()
+	first := bus.Subscribe(100, OverflowBlock)
+	second := bus.Subscribe(100, OverflowDisconnect)
+	for tick := uint64(0); tick < 100; tick++ {
+		bus.Publish(TickChange{Tick: tick})
+	}
+	for _, subscription := range []*Subscription{first, second} {
+		for tick := uint64(0); tick < 100; tick++ {
+			change := <-subscription.Changes
+			if change.(TickChange).Tick != tick {
+				t.Fatalf("expected tick %d, got %+v", tick, change)
+			}
+		}
+	}
+}
+
+func TestOverflowBlockWaitsForRoom(t *testing.T) {
+	bus := NewChangeBus()
+	subscription := bus.Subscribe(1, OverflowBlock)
+	bus.Publish(TickChange{Tick: 1})
+	published := publishAsync(bus, TickChange{Tick: 2})
+
+	select {
+	case <-published:
+		t.Fatalf("expected Publish to wait for a full subscriber")
+	case <-time.After(waitTimeout):
+	}
+	for tick := uint64(1); tick <= 2; tick++ {
+		change := <-subscription.Changes
+		if change.(TickChange).Tick != tick {
+			t.Fatalf("expected tick %d, got %+v", tick, change)
+		}
+	}
+	<-published
+}
+
+func TestOverflowBlockDoesNotStallOthers(t *testing.T) {
+	bus := NewChangeBus()
+	slow := bus.Subscribe(1, OverflowBlock)
+	fast := bus.Subscribe(10, OverflowBlock)
+	bus.Publish(TickChange{Tick: 1})
+	published := publishAsync(bus, TickChange{Tick: 2})
+
+	for tick := uint64(1); tick <= 2; tick++ {
+		select {
+		case change := <-fast.Changes:
+			if change.(TickChange).Tick != tick {
+				t.Fatalf("expected tick %d, got %+v", tick, change)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the fast subscriber to receive tick %d", tick)
+		}
+	}
+
+	subscribed := make(chan struct{})
+	go func() {
+		bus.Subscribe(1, OverflowBlock).Unsubscribe()
+		close(subscribed)
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(time.Second):
+		t.Fatalf("expected Subscribe not to wait for a full subscriber")
+	}
+
+	slow.Unsubscribe()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatalf("expected Unsubscribe to release the publisher")
+	}
+}
+
+func TestOverflowDisconnectClosesChannel(t *testing.T) {
+	bus := NewChangeBus()
+	subscription := bus.Subscribe(1, OverflowDisconnect)
+	bus.Publish(TickChange{Tick: 1})
+	bus.Publish(TickChange{Tick: 2})
+	bus.Publish(TickChange{Tick: 3})
+
+	change, ok := <-subscription.Changes
+	if !ok || change.(TickChange).Tick != 1 {
+		t.Fatalf("expected tick 1 before the channel closed, got %+v", change)
+	}
+	if _, ok := <-subscription.Changes; ok {
+		t.Errorf("expected the channel to be closed after overflowing")
+	}
+	subscription.Unsubscribe()
+}
@@ -0,0 +1,54 @@
+package backend
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time to the game engine. Everything that depends
+// on time, like laser movement, throttling, and round timers, should use a
+// Clock instead of calling time.Now directly.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is a Clock that uses the system time.
+type RealClock struct{}
+
+// Now returns the current system time.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a Clock that only moves when it is advanced manually. Pair it
+// with Game.Step to run the game deterministically.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock constructs a new FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current fake time.
+func (clock *FakeClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return clock.now
+}
+
+// Advance moves the fake time forward by the given duration.
+func (clock *FakeClock) Advance(duration time.Duration) {
+	clock.mu.Lock()
+	clock.now = clock.now.Add(duration)
+	clock.mu.Unlock()
+}
+
+// Set changes the fake time.
+func (clock *FakeClock) Set(now time.Time) {
+	clock.mu.Lock()
+	clock.now = now
+	clock.mu.Unlock()
+}
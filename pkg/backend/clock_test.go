@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMoveThrottleUsesClock(t *testing.T) {
+	game, clock := newTestGame(t, openGrid)
+	player := addTestPlayer(game, "player", Coordinate{X: 0, Y: 0})
+	move := func() {
+		game.actionQueue = append(game.actionQueue, MoveAction{
+			ID:        player.ID(),
+			Direction: DirectionRight,
+			Created:   clock.Now(),
+		})
+		game.Step()
+	}
+
+	move()
+	clock.Advance(moveThrottle / 2)
+	move()
+	if player.Position() != (Coordinate{X: 1, Y: 0}) {
+		t.Fatalf("expected the second move to be throttled, player is at %v", player.Position())
+	}
+	clock.Advance(moveThrottle / 2)
+	move()
+	if player.Position() != (Coordinate{X: 2, Y: 0}) {
+		t.Errorf("expected the player to move after the throttle, player is at %v", player.Position())
+	}
+}
+
+func TestLaserCooldownUsesClock(t *testing.T) {
+	game, clock := newTestGame(t, openGrid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -6, Y: -1})
+
+	fire(game, shooter, DirectionRight)
+	game.Step()
+	clock.Advance(Weapons[WeaponLaser].Cooldown - time.Millisecond)
+	fire(game, shooter, DirectionRight)
+	game.Step()
+	if lasers := countLasers(game); lasers != 1 {
+		t.Fatalf("expected the second laser to be throttled, found %d lasers", lasers)
+	}
+	clock.Advance(time.Millisecond)
+	fire(game, shooter, DirectionRight)
+	game.Step()
+	if lasers := countLasers(game); lasers != 2 {
+		t.Errorf("expected a laser after the cooldown, found %d lasers", lasers)
+	}
+}
+
+func TestLaserTravelsWithClock(t *testing.T) {
+	game, clock := newTestGame(t, openGrid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -6, Y: 0})
+	speed := Weapons[WeaponLaser].Speed
+
+	fire(game, shooter, DirectionRight)
+	game.Step()
+	var laser *Laser
+	for _, entity := range game.Entities {
+		if found, ok := entity.(*Laser); ok {
+			laser = found
+		}
+	}
+	if laser == nil {
+		t.Fatal("expected a laser to be fired")
+	}
+	start := laser.Position()
+
+	// The laser only moves when the clock does.
+	game.Step()
+	if laser.Position() != start {
+		t.Errorf("expected the laser to stay at %v, got %v", start, laser.Position())
+	}
+	clock.Advance(speed*3 - time.Millisecond)
+	game.Step()
+	if expected := start.Add(Coordinate{X: 2}); laser.Position() != expected {
+		t.Errorf("expected the laser to be at %v, got %v", expected, laser.Position())
+	}
+	clock.Advance(time.Millisecond)
+	game.Step()
+	if expected := start.Add(Coordinate{X: 3}); laser.Position() != expected {
+		t.Errorf("expected the laser to be at %v, got %v", expected, laser.Position())
+	}
+
+	// The laser is removed once it reaches the wall.
+	tick := time.Second / DefaultTickRate
+	for i := 0; i < 5*DefaultTickRate && countLasers(game) > 0; i++ {
+		clock.Advance(tick)
+		game.Step()
+	}
+	if lasers := countLasers(game); lasers != 0 {
+		t.Errorf("expected the laser to hit the wall, found %d lasers", lasers)
+	}
+}
+
+func TestRoundTransitionsUseClock(t *testing.T) {
+	game, clock := newTestGame(t, openGrid)
+	winner := addTestPlayer(game, "winner", Coordinate{X: 0, Y: 0})
+	game.Score[winner.ID()] = roundOverScore
+	game.queueNewRound(winner.ID())
+	if expected := clock.Now().Add(newRoundWaitTime); !game.NewRoundAt.Equal(expected) {
+		t.Errorf("expected the new round at %v, got %v", expected, game.NewRoundAt)
+	}
+
+	// Players can not act while waiting for the next round.
+	position := winner.Position()
+	game.actionQueue = append(game.actionQueue, MoveAction{
+		ID:        winner.ID(),
+		Direction: DirectionRight,
+		Created:   clock.Now(),
+	})
+	clock.Advance(newRoundWaitTime - time.Millisecond)
+	game.Step()
+	if !game.WaitForRound {
+		t.Fatalf("expected the round to not start before %s", newRoundWaitTime)
+	}
+	if winner.Position() != position {
+		t.Errorf("expected players to not move between rounds")
+	}
+
+	clock.Advance(time.Millisecond)
+	game.Step()
+	if game.WaitForRound {
+		t.Fatalf("expected a new round to start")
+	}
+	if len(game.Score) != 0 {
+		t.Errorf("expected scores to be reset, got %v", game.Score)
+	}
+}
@@ -0,0 +1,197 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const ctfRoundOverScore = 3
+
+// flagBaseMapTypes maps the map types where flags are placed to their team.
+var flagBaseMapTypes = map[MapType]Team{
+	MapTypeFlagRed:  TeamRed,
+	MapTypeFlagBlue: TeamBlue,
+}
+
+// Flag is an entity that players try to carry from the enemy base to their
+// own base.
+type Flag struct {
+	IdentifierBase
+	Positioner
+	Team            Team
+	Base            Coordinate
+	CurrentPosition Coordinate
+	CarrierID       uuid.UUID
+}
+
+// Position determines the flag position. Carried flags are drawn on their
+// carrier instead.
+func (flag *Flag) Position() Coordinate {
+	return flag.CurrentPosition
+}
+
+// IsCarried determines if a player is carrying the flag.
+func (flag *Flag) IsCarried() bool {
+	return flag.CarrierID != uuid.Nil
+}
+
+// CarriedFlag returns the flag carried by a player, if any.
+func (game *Game) CarriedFlag(playerID uuid.UUID) *Flag {
+	for _, entity := range game.Entities {
+		flag, ok := entity.(*Flag)
+		if ok && flag.CarrierID == playerID {
+			return flag
+		}
+	}
+	return nil
+}
+
+// CaptureTheFlagMode splits players into teams that score by carrying the
+// enemy flag back to their own base. The first team to reach
+// ctfRoundOverScore captures wins the round.
+type CaptureTheFlagMode struct {
+	TeamDeathmatchMode
+	flags map[Team]*Flag
+}
+
+// Name returns the name used to select the mode.
+func (mode *CaptureTheFlagMode) Name() string {
+	return "ctf"
+}
+
+// CheckMap ensures that the map has a flag base for both teams.
+func (mode *CaptureTheFlagMode) CheckMap(gameMap *Map) error {
+	for _, mapType := range []MapType{MapTypeFlagRed, MapTypeFlagBlue} {
+		if !gameMap.hasMapType(mapType) {
+			return fmt.Errorf("map has no %s flag base", flagBaseMapTypes[mapType])
+		}
+	}
+	return nil
+}
+
+// PlayerLeft drops the flag if the player is carrying it.
+func (mode *CaptureTheFlagMode) PlayerLeft(game *Game, player *Player) {
+	mode.dropFlag(game, player)
+}
+
+// PlayerKilled drops the flag where the player died and respawns them. Kills
+// do not score in this mode.
+func (mode *CaptureTheFlagMode) PlayerKilled(game *Game, player *Player, killerID uuid.UUID) {
+	mode.dropFlag(game, player)
+	game.RespawnPlayer(player, killerID)
+}
+
+// RoundStarted returns all flags to their bases.
+func (mode *CaptureTheFlagMode) RoundStarted(game *Game) {
+	mode.resetFlags(game)
+}
+
+// Tick handles players picking up, returning, and capturing flags.
+func (mode *CaptureTheFlagMode) Tick(game *Game) {
+	if mode.flags == nil {
+		mode.resetFlags(game)
+	}
+	for _, flag := range mode.flags {
+		if flag.IsCarried() {
+			carrier, ok := game.GetEntity(flag.CarrierID).(*Player)
+			if !ok {
+				continue
+			}
+			flag.CurrentPosition = carrier.Position()
+			mode.checkCapture(game, flag, carrier)
+			continue
+		}
+		for _, entity := range game.Entities {
+			player, ok := entity.(*Player)
+			if !ok || !player.IsAlive() || player.Position() != flag.CurrentPosition {
+				continue
+			}
+			if player.Team != flag.Team {
+				flag.CarrierID = player.ID()
+				game.sendChange(FlagPickupChange{
+					Flag:   flag,
+					Player: player,
+				})
+				break
+			}
+			if flag.CurrentPosition != flag.Base {
+				flag.CurrentPosition = flag.Base
+				game.sendChange(FlagReturnChange{
+					Flag:   flag,
+					Player: player,
+				})
+				break
+			}
+		}
+	}
+}
+
+// CheckWinCondition ends the round when a team has enough captures.
+func (mode *CaptureTheFlagMode) CheckWinCondition(game *Game) (uuid.UUID, Team, bool) {
+	return checkTeamWinCondition(game, ctfRoundOverScore)
+}
+
+// checkCapture scores a point if the carrier has brought the flag to their
+// base while their own flag is there.
+func (mode *CaptureTheFlagMode) checkCapture(game *Game, flag *Flag, carrier *Player) {
+	ownFlag, ok := mode.flags[carrier.Team]
+	if !ok || ownFlag.IsCarried() || ownFlag.CurrentPosition != ownFlag.Base {
+		return
+	}
+	if carrier.Position() != ownFlag.Base {
+		return
+	}
+	flag.CarrierID = uuid.Nil
+	flag.CurrentPosition = flag.Base
+	game.sendChange(FlagCaptureChange{
+		Flag:   flag,
+		Player: carrier,
+	})
+	game.AddScore(carrier.ID())
+}
+
+// dropFlag drops the flag carried by a player where they are standing.
+func (mode *CaptureTheFlagMode) dropFlag(game *Game, player *Player) {
+	for _, flag := range mode.flags {
+		if flag.CarrierID != player.ID() {
+			continue
+		}
+		flag.CarrierID = uuid.Nil
+		flag.CurrentPosition = player.Position()
+		game.sendChange(FlagDropChange{
+			Flag: flag,
+		})
+	}
+}
+
+// resetFlags removes existing flags and places a new flag on every flag base
+// in the current map.
+func (mode *CaptureTheFlagMode) resetFlags(game *Game) {
+	for _, flag := range mode.flags {
+		game.sendChange(RemoveEntityChange{
+			Entity: flag,
+		})
+		game.RemoveEntity(flag.ID())
+	}
+	mode.flags = make(map[Team]*Flag)
+	for mapType, positions := range game.GetMapByType() {
+		team, ok := flagBaseMapTypes[mapType]
+		if !ok {
+			continue
+		}
+		for _, position := range positions {
+			flag := &Flag{
+				IdentifierBase:  IdentifierBase{uuid.New()},
+				Team:            team,
+				Base:            position,
+				CurrentPosition: position,
+			}
+			mode.flags[team] = flag
+			game.AddEntity(flag)
+			game.sendChange(AddEntityChange{
+				Entity: flag,
+			})
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	roundOverScore     = 10
+	teamRoundOverScore = 30
+)
+
+// DeathmatchMode is a free for all where the first player to reach
+// roundOverScore kills wins the round.
+type DeathmatchMode struct{}
+
+// Name returns the name used to select the mode.
+func (mode *DeathmatchMode) Name() string {
+	return "deathmatch"
+}
+
+// Teams determines if players are split into teams.
+func (mode *DeathmatchMode) Teams() bool {
+	return false
+}
+
+// CheckMap allows every map.
+func (mode *DeathmatchMode) CheckMap(gameMap *Map) error {
+	return nil
+}
+
+// PlayerJoined ensures that players are not on a team.
+func (mode *DeathmatchMode) PlayerJoined(game *Game, player *Player) {
+	player.Team = TeamNone
+}
+
+// PlayerLeft does nothing, as scores are kept until the round is over.
+func (mode *DeathmatchMode) PlayerLeft(game *Game, player *Player) {}
+
+// PlayerKilled respawns the player and gives the killer a point.
+func (mode *DeathmatchMode) PlayerKilled(game *Game, player *Player, killerID uuid.UUID) {
+	game.RespawnPlayer(player, killerID)
+	game.AddScore(killerID)
+}
+
+// RoundStarted does nothing, as scores are reset by the game.
+func (mode *DeathmatchMode) RoundStarted(game *Game) {}
+
+// Tick does nothing, as all scoring happens when players are killed.
+func (mode *DeathmatchMode) Tick(game *Game) {}
+
+// CheckWinCondition ends the round when a player has enough kills.
+func (mode *DeathmatchMode) CheckWinCondition(game *Game) (uuid.UUID, Team, bool) {
+	for id, score := range game.Score {
+		if score >= roundOverScore {
+			return id, TeamNone, true
+		}
+	}
+	return uuid.Nil, TeamNone, false
+}
+
+// Placements orders players by score. Players with the same score are tied,
+// and are ordered by name.
+func (mode *DeathmatchMode) Placements(game *Game) [][]uuid.UUID {
+	players := make([]*Player, 0)
+	for _, entity := range game.Entities {
+		if player, ok := entity.(*Player); ok {
+			players = append(players, player)
+		}
+	}
+	sort.Slice(players, func(i, j int) bool {
+		scoreI, scoreJ := game.Score[players[i].ID()], game.Score[players[j].ID()]
+		if scoreI != scoreJ {
+			return scoreI > scoreJ
+		}
+		return players[i].Name < players[j].Name
+	})
+	placements := make([][]uuid.UUID, 0)
+	for i, player := range players {
+		if i == 0 || game.Score[player.ID()] != game.Score[players[i-1].ID()] {
+			placements = append(placements, make([]uuid.UUID, 0))
+		}
+		last := len(placements) - 1
+		placements[last] = append(placements[last], player.ID())
+	}
+	return placements
+}
+
+// TeamDeathmatchMode splits players into teams, and the first team to reach
+// teamRoundOverScore kills wins the round.
+type TeamDeathmatchMode struct {
+	DeathmatchMode
+}
+
+// Name returns the name used to select the mode.
+func (mode *TeamDeathmatchMode) Name() string {
+	return "teamdeathmatch"
+}
+
+// Teams determines if players are split into teams.
+func (mode *TeamDeathmatchMode) Teams() bool {
+	return true
+}
+
+// PlayerJoined puts players without a team on the smallest team.
+func (mode *TeamDeathmatchMode) PlayerJoined(game *Game, player *Player) {
+	if player.Team != TeamNone {
+		return
+	}
+	player.Team = game.AssignTeam()
+	player.Move(game.NextSpawnPoint(player.Team))
+}
+
+// CheckWinCondition ends the round when a team has enough kills.
+func (mode *TeamDeathmatchMode) CheckWinCondition(game *Game) (uuid.UUID, Team, bool) {
+	return checkTeamWinCondition(game, teamRoundOverScore)
+}
+
+// Placements puts every team in a group of tied players, ordered by team
+// score, as teammates win or lose together. Teams with the same score are
+// tied.
+func (mode *TeamDeathmatchMode) Placements(game *Game) [][]uuid.UUID {
+	members := make(map[Team][]*Player)
+	for _, entity := range game.Entities {
+		if player, ok := entity.(*Player); ok {
+			members[player.Team] = append(members[player.Team], player)
+		}
+	}
+	teams := make([]Team, 0, len(members))
+	scores := make(map[Team]int)
+	for team, players := range members {
+		teams = append(teams, team)
+		scores[team] = game.TeamScore(team)
+		sort.Slice(players, func(i, j int) bool {
+			return players[i].Name < players[j].Name
+		})
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if scores[teams[i]] != scores[teams[j]] {
+			return scores[teams[i]] > scores[teams[j]]
+		}
+		return teams[i] < teams[j]
+	})
+	placements := make([][]uuid.UUID, 0)
+	for i, team := range teams {
+		if i == 0 || scores[team] != scores[teams[i-1]] {
+			placements = append(placements, make([]uuid.UUID, 0))
+		}
+		last := len(placements) - 1
+		for _, player := range members[team] {
+			placements[last] = append(placements[last], player.ID())
+		}
+	}
+	return placements
+}
+
+// checkTeamWinCondition ends the round when a team reaches a score. The player
+// on the team with the highest score is the round winner.
+func checkTeamWinCondition(game *Game, roundOverScore int) (uuid.UUID, Team, bool) {
+	for _, team := range Teams {
+		if game.TeamScore(team) < roundOverScore {
+			continue
+		}
+		winnerID := uuid.Nil
+		for id, score := range game.Score {
+			player, ok := game.GetEntity(id).(*Player)
+			if !ok || player.Team != team {
+				continue
+			}
+			if winnerID == uuid.Nil || score > game.Score[winnerID] {
+				winnerID = id
+			}
+		}
+		return winnerID, team, true
+	}
+	return uuid.Nil, TeamNone, false
+}
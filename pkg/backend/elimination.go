@@ -0,0 +1,104 @@
+package backend
+
+import "github.com/google/uuid"
+
+// EliminationMode is a free for all where killed players spectate until the
+// round is over, and the last player standing wins the round.
+type EliminationMode struct {
+	DeathmatchMode
+	// eliminated contains the IDs of killed players in the order they died.
+	eliminated []uuid.UUID
+	// contested is set once more than one player was alive in the round, so
+	// that a player waiting alone for opponents does not win.
+	contested bool
+}
+
+// Name returns the name used to select the mode.
+func (mode *EliminationMode) Name() string {
+	return "elimination"
+}
+
+// PlayerJoined makes players who join after the first elimination spectate
+// until the next round.
+func (mode *EliminationMode) PlayerJoined(game *Game, player *Player) {
+	mode.DeathmatchMode.PlayerJoined(game, player)
+	if len(mode.eliminated) > 0 {
+		player.State = PlayerDead
+	}
+}
+
+// PlayerLeft removes the player from the placements.
+func (mode *EliminationMode) PlayerLeft(game *Game, player *Player) {
+	for i, id := range mode.eliminated {
+		if id == player.ID() {
+			mode.eliminated = append(mode.eliminated[:i], mode.eliminated[i+1:]...)
+			break
+		}
+	}
+}
+
+// PlayerKilled makes the player spectate and gives the killer a point.
+func (mode *EliminationMode) PlayerKilled(game *Game, player *Player, killerID uuid.UUID) {
+	player.Health = 0
+	player.State = PlayerDead
+	mode.eliminated = append(mode.eliminated, player.ID())
+	change := PlayerDeathChange{
+		Player:     player,
+		KilledByID: killerID,
+	}
+	game.sendChange(change)
+	game.AddScore(killerID)
+}
+
+// RoundStarted clears the placements of the last round.
+func (mode *EliminationMode) RoundStarted(game *Game) {
+	mode.eliminated = nil
+	mode.contested = false
+}
+
+// CheckWinCondition ends the round when at most one player is alive, whether
+// the others were killed or left the game. If the last players died at the
+// same time, the last one to be eliminated wins.
+func (mode *EliminationMode) CheckWinCondition(game *Game) (uuid.UUID, Team, bool) {
+	survivors := mode.survivors(game)
+	if len(survivors) > 1 {
+		mode.contested = true
+		return uuid.Nil, TeamNone, false
+	}
+	if !mode.contested && len(mode.eliminated) == 0 {
+		return uuid.Nil, TeamNone, false
+	}
+	if len(survivors) == 1 {
+		return survivors[0], TeamNone, true
+	}
+	if len(mode.eliminated) > 0 {
+		return mode.eliminated[len(mode.eliminated)-1], TeamNone, true
+	}
+	return uuid.Nil, TeamNone, true
+}
+
+// Placements orders survivors first, who are tied if there is more than one,
+// followed by eliminated players from last to first killed.
+func (mode *EliminationMode) Placements(game *Game) [][]uuid.UUID {
+	placements := make([][]uuid.UUID, 0)
+	survivors := mode.survivors(game)
+	if len(survivors) > 0 {
+		placements = append(placements, survivors)
+	}
+	for i := len(mode.eliminated) - 1; i >= 0; i-- {
+		placements = append(placements, []uuid.UUID{mode.eliminated[i]})
+	}
+	return placements
+}
+
+// survivors returns the IDs of players who are still alive.
+func (mode *EliminationMode) survivors(game *Game) []uuid.UUID {
+	survivors := make([]uuid.UUID, 0)
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if ok && player.IsAlive() {
+			survivors = append(survivors, player.ID())
+		}
+	}
+	return survivors
+}
@@ -0,0 +1,37 @@
+//go:build ignore
+// +build ignore
+
+// This program generates map_default.go from maps/default.txt, so that the
+// default map is available without reading files at runtime. Run it with
+// "go generate ./pkg/backend".
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+func main() {
+	source, err := ioutil.ReadFile("../../maps/default.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if bytes.ContainsRune(source, '`') {
+		log.Fatal("maps/default.txt can not contain backticks")
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by gen_map_default.go from maps/default.txt. DO NOT EDIT.\n\n")
+	buffer.WriteString("package backend\n\n")
+	buffer.WriteString("// mapDefaultSource is the contents of maps/default.txt.\n")
+	buffer.WriteString("const mapDefaultSource = `" + strings.Replace(string(source), "\r", "", -1) + "`\n")
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("map_default.go", formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
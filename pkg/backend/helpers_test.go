@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// openGrid is a small map with two open rows.
+const openGrid = "" +
+	"███████████████\n" +
+	"█S           S█\n" +
+	"█             █\n" +
+	"███████████████\n"
+
+// newTestGame creates a game on the given map that is driven by a fake clock.
+func newTestGame(t *testing.T, grid string) (*Game, *FakeClock) {
+	t.Helper()
+	gameMap, err := ParseMap(strings.NewReader("name: Test\n---\n" + grid))
+	if err != nil {
+		t.Fatalf("can not parse map: %v", err)
+	}
+	clock := NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	game := NewGame()
+	game.Clock = clock
+	game.SetMap(gameMap)
+	return game, clock
+}
+
+// addTestPlayer adds a player to the game at the given position.
+func addTestPlayer(game *Game, name string, position Coordinate) *Player {
+	player := &Player{
+		IdentifierBase:  IdentifierBase{UUID: uuid.New()},
+		Name:            name,
+		Icon:            rune(name[0]),
+		CurrentPosition: position,
+		Health:          game.MaxHealth,
+	}
+	game.AddEntity(player)
+	return player
+}
+
+// fire queues a laser fired by a player, to be performed on the next step.
+func fire(game *Game, player *Player, direction Direction) {
+	game.actionQueue = append(game.actionQueue, LaserAction{
+		Direction: direction,
+		ID:        uuid.New(),
+		OwnerID:   player.ID(),
+		Created:   game.Clock.Now(),
+	})
+}
+
+// countLasers returns the number of lasers in the game.
+func countLasers(game *Game) int {
+	lasers := 0
+	for _, entity := range game.Entities {
+		if _, ok := entity.(*Laser); ok {
+			lasers++
+		}
+	}
+	return lasers
+}
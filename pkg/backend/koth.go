@@ -0,0 +1,125 @@
+package backend
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	kothRoundOverScore = 60
+	hillScoreInterval  = time.Second
+	hillMoveInterval   = 30 * time.Second
+)
+
+// KingOfTheHillMode is a free for all where players score a point every
+// second they spend alone on the hill. If a map defines more than one hill,
+// the hill moves to the next one every hillMoveInterval. The first player to
+// reach kothRoundOverScore wins the round.
+type KingOfTheHillMode struct {
+	DeathmatchMode
+	hillIndex   int
+	hillMap     *Map
+	nextScoreAt time.Time
+}
+
+// Name returns the name used to select the mode.
+func (mode *KingOfTheHillMode) Name() string {
+	return "koth"
+}
+
+// CheckMap ensures that the map has a hill.
+func (mode *KingOfTheHillMode) CheckMap(gameMap *Map) error {
+	if len(gameMap.Hills) == 0 {
+		return errors.New("map has no hills")
+	}
+	return nil
+}
+
+// PlayerKilled respawns the player. Kills do not score in this mode.
+func (mode *KingOfTheHillMode) PlayerKilled(game *Game, player *Player, killerID uuid.UUID) {
+	game.RespawnPlayer(player, killerID)
+}
+
+// RoundStarted moves the hill back to its first position.
+func (mode *KingOfTheHillMode) RoundStarted(game *Game) {
+	mode.moveHill(game, 0)
+}
+
+// Tick moves the hill and gives a point to the player holding it.
+func (mode *KingOfTheHillMode) Tick(game *Game) {
+	if mode.hillMap != game.gameMap {
+		mode.moveHill(game, 0)
+	}
+	if game.Hill == nil {
+		return
+	}
+	now := game.Clock.Now()
+	if !game.HillMovesAt.IsZero() && !now.Before(game.HillMovesAt) {
+		mode.moveHill(game, (mode.hillIndex+1)%len(game.gameMap.Hills))
+	}
+	if now.Before(mode.nextScoreAt) {
+		return
+	}
+	mode.nextScoreAt = now.Add(hillScoreInterval)
+	king := game.HillKing()
+	if king != nil {
+		game.AddScore(king.ID())
+	}
+}
+
+// CheckWinCondition ends the round when a player has held the hill long
+// enough.
+func (mode *KingOfTheHillMode) CheckWinCondition(game *Game) (uuid.UUID, Team, bool) {
+	for id, score := range game.Score {
+		if score >= kothRoundOverScore {
+			return id, TeamNone, true
+		}
+	}
+	return uuid.Nil, TeamNone, false
+}
+
+// moveHill makes one of the current map's hills active. There is no active
+// hill if the map does not define any.
+func (mode *KingOfTheHillMode) moveHill(game *Game, index int) {
+	mode.hillMap = game.gameMap
+	mode.hillIndex = index
+	hills := game.gameMap.Hills
+	now := game.Clock.Now()
+	game.Hill = nil
+	game.HillMovesAt = time.Time{}
+	if len(hills) > 0 {
+		hill := game.gameMap.hill(index)
+		game.Hill = &hill
+	}
+	if len(hills) > 1 {
+		game.HillMovesAt = now.Add(hillMoveInterval)
+	}
+	mode.nextScoreAt = now.Add(hillScoreInterval)
+	change := HillChange{
+		Hill:    game.Hill,
+		MovesAt: game.HillMovesAt,
+	}
+	game.sendChange(change)
+}
+
+// HillKing returns the only player on the hill, or nil if the hill is empty
+// or contested.
+func (game *Game) HillKing() *Player {
+	if game.Hill == nil {
+		return nil
+	}
+	var king *Player
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if !ok || !player.IsAlive() || !game.Hill.Contains(player.Position()) {
+			continue
+		}
+		if king != nil {
+			return nil
+		}
+		king = player
+	}
+	return king
+}
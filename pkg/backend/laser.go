@@ -16,13 +16,44 @@ type Laser struct {
 	Direction       Direction
 	StartTime       time.Time
 	OwnerID         uuid.UUID
+	Weapon          WeaponType
+	// VolleyIndex is the position of the laser in the volley it was fired
+	// in. Only the first laser, with an index of zero, has the ID of the
+	// LaserAction that fired it.
+	VolleyIndex int
+	// Rewind is how far back in time players are checked for hits, to
+	// make up for the shooter's latency.
+	Rewind time.Duration
+	clock  Clock
+	// nextMove is the first move that has not been checked for collisions.
+	nextMove int
+}
+
+// now returns the current time according to the laser's clock, which is set
+// when the laser is added to a game.
+func (laser *Laser) now() time.Time {
+	if laser.clock == nil {
+		return time.Now()
+	}
+	return laser.clock.Now()
 }
 
 // Position returns the laser position, which is calculated at runtime based on
 // when the laser was fired.
 func (laser *Laser) Position() Coordinate {
-	difference := time.Now().Sub(laser.StartTime)
-	moves := int(math.Floor(float64(difference.Milliseconds()) / float64(laserSpeed)))
+	return laser.positionAfter(laser.moves())
+}
+
+// moves returns the number of tiles the laser has moved since it was fired.
+func (laser *Laser) moves() int {
+	difference := laser.now().Sub(laser.StartTime)
+	speed := Weapons[laser.Weapon].Speed
+	return int(math.Floor(float64(difference.Milliseconds()) / float64(speed.Milliseconds())))
+}
+
+// positionAfter returns the position of the laser after it has moved the
+// given number of tiles.
+func (laser *Laser) positionAfter(moves int) Coordinate {
 	position := laser.InitialPosition
 	switch laser.Direction {
 	case DirectionUp:
@@ -43,40 +74,77 @@ type LaserAction struct {
 	ID        uuid.UUID
 	OwnerID   uuid.UUID
 	Created   time.Time
+	// ViewTime is the game time the shooter was seeing when they fired,
+	// which is used to compensate for their latency. It is zero for local
+	// players.
+	ViewTime time.Time
 }
 
-// Perform spawns a laser next to the player who fired it.
+// Perform spawns lasers next to the player who fired them, based on the
+// player's weapon.
 func (action LaserAction) Perform(game *Game) {
 	entity := game.GetEntity(action.OwnerID)
 	if entity == nil {
 		return
 	}
-	actionKey := fmt.Sprintf("%T:%s", action, entity.ID().String())
-	if !game.checkLastActionTime(actionKey, action.Created, laserThrottle) {
+	weaponType := WeaponLaser
+	player, ok := entity.(*Player)
+	if ok && !player.IsAlive() {
 		return
 	}
-	laser := Laser{
-		InitialPosition: entity.(Positioner).Position(),
-		StartTime:       action.Created,
-		Direction:       action.Direction,
-		IdentifierBase:  IdentifierBase{action.ID},
-		OwnerID:         action.OwnerID,
+	if ok {
+		weaponType = player.Weapon
 	}
-	// Initialize the laser to the side of the player.
-	switch action.Direction {
-	case DirectionUp:
-		laser.InitialPosition.Y--
-	case DirectionDown:
-		laser.InitialPosition.Y++
-	case DirectionLeft:
-		laser.InitialPosition.X--
-	case DirectionRight:
-		laser.InitialPosition.X++
+	weapon := Weapons[weaponType]
+	cooldown := weapon.Cooldown
+	if ok && player.HasEffect(PickupRapidFire, game.Clock.Now()) {
+		cooldown /= 2
+	}
+	actionKey := fmt.Sprintf("%T:%s", action, entity.ID().String())
+	if !game.checkLastActionTime(actionKey, action.Created, cooldown) {
+		return
 	}
-	game.AddEntity(&laser)
-	change := AddEntityChange{
-		Entity: &laser,
+	for i := 0; i < weapon.Spread; i++ {
+		id := action.ID
+		if i > 0 {
+			// Derive IDs so that every game creates the same lasers.
+			id = uuid.NewSHA1(action.ID, []byte{byte(i)})
+		}
+		laser := Laser{
+			InitialPosition: entity.(Positioner).Position(),
+			StartTime:       action.Created,
+			Direction:       action.Direction,
+			IdentifierBase:  IdentifierBase{id},
+			OwnerID:         action.OwnerID,
+			Weapon:          weaponType,
+			VolleyIndex:     i,
+			Rewind:          game.rewindFor(action.ViewTime),
+		}
+		// Spread lasers out to alternating sides of the first laser.
+		offset := (i + 1) / 2
+		if i%2 == 1 {
+			offset = -offset
+		}
+		// Initialize the laser to the side of the player.
+		switch action.Direction {
+		case DirectionUp:
+			laser.InitialPosition.Y--
+			laser.InitialPosition.X += offset
+		case DirectionDown:
+			laser.InitialPosition.Y++
+			laser.InitialPosition.X += offset
+		case DirectionLeft:
+			laser.InitialPosition.X--
+			laser.InitialPosition.Y += offset
+		case DirectionRight:
+			laser.InitialPosition.X++
+			laser.InitialPosition.Y += offset
+		}
+		game.AddEntity(&laser)
+		change := AddEntityChange{
+			Entity: &laser,
+		}
+		game.sendChange(change)
 	}
-	game.sendChange(change)
 	game.updateLastActionTime(actionKey, action.Created)
 }
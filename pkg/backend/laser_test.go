@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFastLaserStopsAtWall(t *testing.T) {
+	grid := "" +
+		"███████████████\n" +
+		"█             █\n" +
+		"█S     █     S█\n" +
+		"███████████████\n"
+	// The sniper moves faster than the game ticks, so try lasers fired at
+	// every point between two ticks.
+	tick := time.Second / DefaultTickRate
+	for offset := time.Duration(0); offset < Weapons[WeaponSniper].Speed; offset += time.Millisecond {
+		game, clock := newTestGame(t, grid)
+		shooter := addTestPlayer(game, "shooter", Coordinate{X: -6, Y: 0})
+		shooter.Weapon = WeaponSniper
+		target := addTestPlayer(game, "target", Coordinate{X: 6, Y: 0})
+
+		// Lasers are fired between ticks.
+		fire(game, shooter, DirectionRight)
+		clock.Advance(offset)
+		for i := 0; i < DefaultTickRate; i++ {
+			game.Step()
+			clock.Advance(tick)
+		}
+		if target.Health != game.MaxHealth {
+			t.Errorf("offset %s: laser went through a wall and hit the target", offset)
+		}
+		if lasers := countLasers(game); lasers != 0 {
+			t.Errorf("offset %s: expected the laser to be removed, found %d lasers", offset, lasers)
+		}
+	}
+}
+
+func TestFastLaserHitsPlayerBetweenTicks(t *testing.T) {
+	grid := "" +
+		"███████████████\n" +
+		"█S           S█\n" +
+		"███████████████\n"
+	game, clock := newTestGame(t, grid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -6, Y: 0})
+	shooter.Weapon = WeaponSniper
+	target := addTestPlayer(game, "target", Coordinate{X: -3, Y: 0})
+
+	// At five ticks per second, the laser moves past the target in a single
+	// tick.
+	fire(game, shooter, DirectionRight)
+	game.Step()
+	clock.Advance(200 * time.Millisecond)
+	game.Step()
+	expected := game.MaxHealth - Weapons[WeaponSniper].Damage
+	if target.Health != expected {
+		t.Errorf("expected target health to be %d, got %d", expected, target.Health)
+	}
+	if lasers := countLasers(game); lasers != 0 {
+		t.Errorf("expected the laser to be removed, found %d lasers", lasers)
+	}
+}
+
+func TestLaserPassesOverPickupsAndFlags(t *testing.T) {
+	grid := "" +
+		"███████████████\n" +
+		"█S     +     S█\n" +
+		"███████████████\n"
+	game, clock := newTestGame(t, grid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -6, Y: 0})
+	target := addTestPlayer(game, "target", Coordinate{X: 6, Y: 0})
+	game.AddEntity(&Flag{
+		IdentifierBase:  IdentifierBase{UUID: uuid.New()},
+		Team:            TeamRed,
+		Base:            Coordinate{X: 3, Y: 0},
+		CurrentPosition: Coordinate{X: 3, Y: 0},
+	})
+	// Spawn the pickup before firing.
+	game.Step()
+
+	fire(game, shooter, DirectionRight)
+	tick := time.Second / DefaultTickRate
+	for i := 0; i < DefaultTickRate*2; i++ {
+		game.Step()
+		clock.Advance(tick)
+	}
+	expected := game.MaxHealth - Weapons[WeaponLaser].Damage
+	if target.Health != expected {
+		t.Errorf("expected target health to be %d, got %d", expected, target.Health)
+	}
+	pickups := 0
+	for _, entity := range game.Entities {
+		if _, ok := entity.(*Pickup); ok {
+			pickups++
+		}
+	}
+	if pickups != 1 {
+		t.Errorf("expected the pickup to still be on the map, found %d pickups", pickups)
+	}
+}
@@ -1,5 +1,17 @@
 package backend
 
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
 // MapType describe the type of a point on the map.
 type MapType int
 
@@ -7,23 +19,357 @@ const (
 	MapTypeNone MapType = iota
 	MapTypeWall
 	MapTypeSpawn
+	MapTypeHealth
+	MapTypeSpeedBoost
+	MapTypeRapidFire
+	MapTypeShield
+	MapTypeSpawnRed
+	MapTypeSpawnBlue
+	MapTypeFlagRed
+	MapTypeFlagBlue
+)
+
+// mapGlyphTypes maps the symbols used in map grids to map types.
+var mapGlyphTypes = map[rune]MapType{
+	' ': MapTypeNone,
+	'█': MapTypeWall,
+	'S': MapTypeSpawn,
+	'+': MapTypeHealth,
+	'>': MapTypeSpeedBoost,
+	'*': MapTypeRapidFire,
+	'#': MapTypeShield,
+	'1': MapTypeSpawnRed,
+	'2': MapTypeSpawnBlue,
+	'R': MapTypeFlagRed,
+	'B': MapTypeFlagBlue,
+}
+
+const (
+	mapGlyphWall = '█'
+	// mapHeaderSeparator separates the metadata header from the grid in map
+	// files.
+	mapHeaderSeparator = "---"
+	minSpawnPoints     = 1
 )
 
+// Map contains the layout of a map and information about it.
+type Map struct {
+	Name       string
+	Author     string
+	MinPlayers int
+	MaxPlayers int
+	// Hills are in grid coordinates, where the top left corner is 0, 0.
+	Hills []Rectangle
+	Grid  [][]rune
+}
+
+// ID returns an identifier based on the map's content, which is used to check
+// if two games are using the same map.
+func (gameMap *Map) ID() string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s\n%s\n%d\n%d\n%v\n", gameMap.Name, gameMap.Author, gameMap.MinPlayers, gameMap.MaxPlayers, gameMap.Hills)
+	for _, row := range gameMap.Grid {
+		fmt.Fprintln(hash, string(row))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Rectangle is a rectangular area of the map.
+type Rectangle struct {
+	Position Coordinate
+	Width    int
+	Height   int
+}
+
+// Contains determines if a coordinate is inside the rectangle.
+func (rect Rectangle) Contains(coordinate Coordinate) bool {
+	return coordinate.X >= rect.Position.X &&
+		coordinate.Y >= rect.Position.Y &&
+		coordinate.X < rect.Position.X+rect.Width &&
+		coordinate.Y < rect.Position.Y+rect.Height
+}
+
+// LoadMap reads and validates a map file.
+func LoadMap(path string) (*Map, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gameMap, err := ParseMap(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return gameMap, nil
+}
+
+// ParseMap reads and validates a map. Maps start with a header of "key: value"
+// lines, followed by a "---" line and then the grid, for example:
+//
+//	name: Arena
+//	author: Alice
+//	minplayers: 2
+//	maxplayers: 8
+//	hill: 2 2 1 1
+//	---
+//	█████
+//	█S S█
+//	█████
+//
+// In the grid "█" is a wall, "S" is a spawn point, and " " is empty space.
+// Pickups spawn on "+" (health), ">" (speed boost), "*" (rapid fire), and "#"
+// (shield). In team games "1" and "2" are spawn points for the red and blue
+// teams, and "R" and "B" are their flag bases in capture the flag.
+//
+// The "hill" header can be repeated to define king of the hill zones, given as
+// "column row width height" where the top left corner is column 1, row 1. The
+// hill moves between zones in the order they are defined.
+func ParseMap(reader io.Reader) (*Map, error) {
+	gameMap := &Map{}
+	scanner := bufio.NewScanner(reader)
+	inHeader := true
+	lineNumber := 0
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		lineNumber++
+		if !inHeader {
+			gameMap.Grid = append(gameMap.Grid, []rune(line))
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == mapHeaderSeparator {
+			inHeader = false
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if err := gameMap.parseHeaderLine(trimmed); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inHeader {
+		return nil, fmt.Errorf("missing %q line after the header", mapHeaderSeparator)
+	}
+	// Ignore trailing blank lines.
+	for len(gameMap.Grid) > 0 && len(gameMap.Grid[len(gameMap.Grid)-1]) == 0 {
+		gameMap.Grid = gameMap.Grid[:len(gameMap.Grid)-1]
+	}
+	if err := gameMap.Validate(); err != nil {
+		return nil, err
+	}
+	return gameMap, nil
+}
+
+// parseHeaderLine parses a single "key: value" line of the map header.
+func (gameMap *Map) parseHeaderLine(line string) error {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid header %q", line)
+	}
+	key := strings.ToLower(strings.TrimSpace(parts[0]))
+	value := strings.TrimSpace(parts[1])
+	var err error
+	switch key {
+	case "name":
+		gameMap.Name = value
+	case "author":
+		gameMap.Author = value
+	case "minplayers":
+		gameMap.MinPlayers, err = strconv.Atoi(value)
+	case "maxplayers":
+		gameMap.MaxPlayers, err = strconv.Atoi(value)
+	case "hill":
+		var hill Rectangle
+		hill, err = parseHill(value)
+		gameMap.Hills = append(gameMap.Hills, hill)
+	default:
+		return fmt.Errorf("unknown header %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %v", key, err)
+	}
+	return nil
+}
+
+// parseHill parses a "column row width height" hill value.
+func parseHill(value string) (Rectangle, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return Rectangle{}, errors.New("expected column, row, width, and height")
+	}
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return Rectangle{}, err
+		}
+		numbers[i] = number
+	}
+	return Rectangle{
+		Position: Coordinate{X: numbers[0] - 1, Y: numbers[1] - 1},
+		Width:    numbers[2],
+		Height:   numbers[3],
+	}, nil
+}
+
+// center returns the grid position of the map's center, which is the origin
+// of game coordinates.
+func (gameMap *Map) center() Coordinate {
+	return Coordinate{X: len(gameMap.Grid[0]) / 2, Y: len(gameMap.Grid) / 2}
+}
+
+// hill returns one of the map's hills in game coordinates, which are centered
+// on the map like player positions.
+func (gameMap *Map) hill(index int) Rectangle {
+	hill := gameMap.Hills[index]
+	hill.Position = hill.Position.Sub(gameMap.center())
+	return hill
+}
+
+// Validate ensures that a map is playable. Maps must be rectangular, enclosed
+// by walls, and have enough spawn points for the minimum number of players,
+// all of which must be reachable from each other.
+func (gameMap *Map) Validate() error {
+	if len(gameMap.Grid) == 0 || len(gameMap.Grid[0]) == 0 {
+		return errors.New("map is empty")
+	}
+	if gameMap.MinPlayers < 0 || gameMap.MaxPlayers < 0 {
+		return errors.New("player limits can not be negative")
+	}
+	if gameMap.MaxPlayers > 0 && gameMap.MinPlayers > gameMap.MaxPlayers {
+		return errors.New("minplayers can not be larger than maxplayers")
+	}
+	width := len(gameMap.Grid[0])
+	height := len(gameMap.Grid)
+	spawnPoints := make([]Coordinate, 0)
+	flagBases := make(map[MapType][]Coordinate)
+	for y, row := range gameMap.Grid {
+		if len(row) != width {
+			return fmt.Errorf("row %d has %d columns, expected %d", y+1, len(row), width)
+		}
+		for x, glyph := range row {
+			mapType, ok := mapGlyphTypes[glyph]
+			if !ok {
+				return fmt.Errorf("unknown symbol %q at row %d, column %d", glyph, y+1, x+1)
+			}
+			if _, ok := spawnMapTypes[mapType]; ok {
+				spawnPoints = append(spawnPoints, Coordinate{X: x, Y: y})
+			}
+			if _, ok := flagBaseMapTypes[mapType]; ok {
+				flagBases[mapType] = append(flagBases[mapType], Coordinate{X: x, Y: y})
+			}
+			onBorder := x == 0 || y == 0 || x == width-1 || y == height-1
+			if onBorder && glyph != mapGlyphWall {
+				return fmt.Errorf("map border is open at row %d, column %d", y+1, x+1)
+			}
+		}
+	}
+	requiredSpawnPoints := minSpawnPoints
+	if gameMap.MinPlayers > requiredSpawnPoints {
+		requiredSpawnPoints = gameMap.MinPlayers
+	}
+	if len(spawnPoints) < requiredSpawnPoints {
+		return fmt.Errorf("map has %d spawn points, at least %d are required", len(spawnPoints), requiredSpawnPoints)
+	}
+	for mapType, positions := range flagBases {
+		if len(positions) > 1 {
+			return fmt.Errorf("map has %d %s flag bases, at most 1 is allowed", len(positions), flagBaseMapTypes[mapType])
+		}
+	}
+	// Flood fill from the first spawn point to ensure all others can be
+	// reached.
+	reachable := map[Coordinate]bool{spawnPoints[0]: true}
+	queue := []Coordinate{spawnPoints[0]}
+	for len(queue) > 0 {
+		position := queue[0]
+		queue = queue[1:]
+		for _, difference := range []Coordinate{
+			{X: -1, Y: 0},
+			{X: 1, Y: 0},
+			{X: 0, Y: -1},
+			{X: 0, Y: 1},
+		} {
+			neighbor := position.Add(difference)
+			if neighbor.X < 0 || neighbor.Y < 0 || neighbor.X >= width || neighbor.Y >= height {
+				continue
+			}
+			if reachable[neighbor] || gameMap.Grid[neighbor.Y][neighbor.X] == mapGlyphWall {
+				continue
+			}
+			reachable[neighbor] = true
+			queue = append(queue, neighbor)
+		}
+	}
+	for _, spawnPoint := range spawnPoints {
+		if !reachable[spawnPoint] {
+			return fmt.Errorf("spawn point at row %d, column %d can not be reached", spawnPoint.Y+1, spawnPoint.X+1)
+		}
+	}
+	for _, positions := range flagBases {
+		for _, flagBase := range positions {
+			if !reachable[flagBase] {
+				return fmt.Errorf("flag base at row %d, column %d can not be reached", flagBase.Y+1, flagBase.X+1)
+			}
+		}
+	}
+	for i, hill := range gameMap.Hills {
+		if hill.Width <= 0 || hill.Height <= 0 {
+			return fmt.Errorf("hill %d must have a positive width and height", i+1)
+		}
+		if hill.Position.X < 0 || hill.Position.Y < 0 || hill.Position.X+hill.Width > width || hill.Position.Y+hill.Height > height {
+			return fmt.Errorf("hill %d is outside of the map", i+1)
+		}
+		hillReachable := false
+		for y := hill.Position.Y; y < hill.Position.Y+hill.Height; y++ {
+			for x := hill.Position.X; x < hill.Position.X+hill.Width; x++ {
+				if reachable[Coordinate{X: x, Y: y}] {
+					hillReachable = true
+				}
+			}
+		}
+		if !hillReachable {
+			return fmt.Errorf("hill %d can not be reached", i+1)
+		}
+	}
+	return nil
+}
+
+// hasMapType determines if any point on the map has the given type.
+func (gameMap *Map) hasMapType(mapType MapType) bool {
+	for _, row := range gameMap.Grid {
+		for _, glyph := range row {
+			if mapGlyphTypes[glyph] == mapType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetMap changes the map the game is played on.
+func (game *Game) SetMap(gameMap *Map) {
+	game.gameMap = gameMap
+	game.spawnPointIndex = 0
+}
+
+// GetMap returns the map the game is played on.
+func (game *Game) GetMap() *Map {
+	return game.gameMap
+}
+
 // GetMapByType returns a map of map types to sets to coordinates.
 func (game *Game) GetMapByType() map[MapType][]Coordinate {
 	width, height := game.GetMapDimensions()
 	mapCenterX := width / 2
 	mapCenterY := height / 2
 	symbols := make(map[MapType][]Coordinate, 0)
-	for mapY, row := range game.gameMap {
+	for mapY, row := range game.gameMap.Grid {
 		for mapX, col := range row {
-			mapType := MapTypeNone
-			switch col {
-			case '█':
-				mapType = MapTypeWall
-			case 'S':
-				mapType = MapTypeSpawn
-			}
+			mapType := mapGlyphTypes[col]
 			symbols[mapType] = append(symbols[mapType], Coordinate{
 				X: mapX - mapCenterX,
 				Y: mapY - mapCenterY,
@@ -35,49 +381,52 @@ func (game *Game) GetMapByType() map[MapType][]Coordinate {
 
 // GetMapDimensions returns the dimensions of the map.
 func (game *Game) GetMapDimensions() (int, int) {
-	return len(game.gameMap[0]), len(game.gameMap)
-}
-
-// MapDefault is the default map used by the game.
-var MapDefault = [][]rune{
-	{'█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', '█', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', '█', '█', '█', '█', '█', '█', ' ', 'S', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', 'S', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', '█'},
-	{'█', ' ', ' ', 'S', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', '█'},
-	{'█', ' ', ' ', '█', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', '█', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', '█', '█', '█', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', 'S', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', 'S', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', 'S', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', '█', '█', '█', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', '█', '█', '█', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', 'S', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', 'S', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', 'S', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', '█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', ' ', '█'},
-	{'█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█', '█'},
+	return len(game.gameMap.Grid[0]), len(game.gameMap.Grid)
+}
+
+// SpawnPoints returns the spawn points that players on a team can use. If the
+// map has no spawn points for the team, every spawn point can be used.
+func (game *Game) SpawnPoints(team Team) []Coordinate {
+	spawnPoints := make([]Coordinate, 0)
+	teamSpawnPoints := make([]Coordinate, 0)
+	mapByType := game.GetMapByType()
+	// Iterate in a fixed order so that spawn points are always cycled through
+	// the same way.
+	for _, mapType := range []MapType{MapTypeSpawn, MapTypeSpawnRed, MapTypeSpawnBlue} {
+		spawnTeam := spawnMapTypes[mapType]
+		positions := mapByType[mapType]
+		spawnPoints = append(spawnPoints, positions...)
+		if team != TeamNone && spawnTeam == team {
+			teamSpawnPoints = append(teamSpawnPoints, positions...)
+		}
+	}
+	if len(teamSpawnPoints) > 0 {
+		return teamSpawnPoints
+	}
+	return spawnPoints
+}
+
+// NextSpawnPoint returns the next spawn point that should be used by a player
+// on the given team, cycling through all of the team's spawn points.
+func (game *Game) NextSpawnPoint(team Team) Coordinate {
+	spawnPoints := game.SpawnPoints(team)
+	spawnPoint := spawnPoints[game.spawnPointIndex%len(spawnPoints)]
+	game.spawnPointIndex++
+	return spawnPoint
+}
+
+//go:generate go run gen_map_default.go
+
+// MapDefault is the default map used by the game, parsed from
+// maps/default.txt.
+var MapDefault = mustParseMap(mapDefaultSource)
+
+// mustParseMap parses a map that is built into the game, panicking if it is
+// invalid.
+func mustParseMap(source string) *Map {
+	gameMap, err := ParseMap(strings.NewReader(source))
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in map: %v", err))
+	}
+	return gameMap
 }
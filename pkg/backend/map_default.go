@@ -0,0 +1,51 @@
+// Code generated by gen_map_default.go from maps/default.txt. DO NOT EDIT.
+
+package backend
+
+// mapDefaultSource is the contents of maps/default.txt.
+const mapDefaultSource = `name: Default
+author: Samuel Mortenson
+minplayers: 2
+maxplayers: 8
+---
+████████████████████████████████████████
+█                                      █
+█                                      █
+█  █  █                       ███████ S█
+█                   S               █  █
+█  S █                              █  █
+█                                   █  █
+█  █  █                             █  █
+█                                   █  █
+█    █                              █  █
+█                                      █
+█  █  █           █   █                █
+█                 █████                █
+█                                      █
+█                                      █
+█                          █           █
+█                          █           █
+█                          █S          █
+█                          █           █
+█                                      █
+█                   S                  █
+█                                      █
+█            █                         █
+█            █                         █
+█           S█                         █
+█            █                         █
+█  ████                                █
+█     █                                █
+█     █           █████                █
+█     █           █   █                █
+█     █                                █
+█     █                                █
+█  S  █                             S  █
+█     █                                █
+█     █                                █
+█     █             S                  █
+█     █                                █
+█                                      █
+█                                      █
+████████████████████████████████████████
+`
@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		err    string
+	}{
+		{
+			name:   "valid",
+			source: "name: Test\nminplayers: 2\n---\n█████\n█S S█\n█████\n",
+		},
+		{
+			name:   "malformed header",
+			source: "name Test\n---\n█████\n█S S█\n█████\n",
+			err:    `line 1: invalid header "name Test"`,
+		},
+		{
+			name:   "unknown header",
+			source: "color: red\n---\n█████\n█S S█\n█████\n",
+			err:    `line 1: unknown header "color"`,
+		},
+		{
+			name:   "invalid number",
+			source: "minplayers: two\n---\n█████\n█S S█\n█████\n",
+			err:    "line 1: invalid value for minplayers",
+		},
+		{
+			name:   "missing separator",
+			source: "name: Test\n",
+			err:    `missing "---" line after the header`,
+		},
+		{
+			name:   "ragged rows",
+			source: "---\n█████\n█S S█\n████\n",
+			err:    "row 3 has 4 columns, expected 5",
+		},
+		{
+			name:   "missing spawn",
+			source: "---\n█████\n█   █\n█████\n",
+			err:    "map has 0 spawn points, at least 1 are required",
+		},
+		{
+			name:   "unknown tile",
+			source: "---\n█████\n█S?S█\n█████\n",
+			err:    `unknown symbol '?' at row 2, column 3`,
+		},
+		{
+			name:   "open border",
+			source: "---\n█████\n█S S \n█████\n",
+			err:    "map border is open at row 2, column 5",
+		},
+		{
+			name:   "unreachable spawn",
+			source: "---\n█████\n█S█S█\n█████\n",
+			err:    "spawn point at row 2, column 4 can not be reached",
+		},
+		{
+			name:   "hill outside of the map",
+			source: "hill: 4 2 3 1\n---\n█████\n█S S█\n█████\n",
+			err:    "hill 1 is outside of the map",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gameMap, err := ParseMap(strings.NewReader(test.source))
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if gameMap.Validate() != nil {
+					t.Errorf("expected the parsed map to still be valid")
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestMapDefaultMatchesSource(t *testing.T) {
+	source, err := ioutil.ReadFile(filepath.Join("..", "..", "maps", "default.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(source) != mapDefaultSource {
+		t.Errorf("map_default.go is out of date, run go generate ./pkg/backend")
+	}
+}
+
+func TestBundledMapsAreValid(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "maps", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		if _, err := LoadMap(path); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// GameMode contains the rules of the game, like how players score and when a
+// round is over. The engine calls a mode's hooks while holding the game lock,
+// and only when the game is authoritative.
+type GameMode interface {
+	// Name returns the name used to select the mode.
+	Name() string
+	// Teams determines if players are split into teams.
+	Teams() bool
+	// CheckMap determines if the mode can be played on a map, for example
+	// if the map has everything the mode's objectives need.
+	CheckMap(gameMap *Map) error
+	// PlayerJoined is called after a player is added to the game.
+	PlayerJoined(game *Game, player *Player)
+	// PlayerLeft is called before a player is removed from the game.
+	PlayerLeft(game *Game, player *Player)
+	// PlayerKilled is called when a player runs out of health.
+	PlayerKilled(game *Game, player *Player, killerID uuid.UUID)
+	// RoundStarted is called after players are reset for a new round.
+	RoundStarted(game *Game)
+	// Tick is called once per tick while a round is in progress.
+	Tick(game *Game)
+	// CheckWinCondition determines if the round is over, and if so which
+	// player and team won.
+	CheckWinCondition(game *Game) (winnerID uuid.UUID, winnerTeam Team, over bool)
+	// Placements returns groups of player IDs ordered from first to last
+	// place, and is called when the round is over. Players in the same group
+	// are tied.
+	Placements(game *Game) [][]uuid.UUID
+}
+
+// GameModes contains constructors for every game mode, keyed by name.
+var GameModes = map[string]func() GameMode{
+	"deathmatch":     func() GameMode { return &DeathmatchMode{} },
+	"teamdeathmatch": func() GameMode { return &TeamDeathmatchMode{} },
+	"ctf":            func() GameMode { return &CaptureTheFlagMode{} },
+	"koth":           func() GameMode { return &KingOfTheHillMode{} },
+	"elimination":    func() GameMode { return &EliminationMode{} },
+}
+
+// GameModeNames returns the names of every game mode, in alphabetical order.
+func GameModeNames() []string {
+	names := make([]string, 0, len(GameModes))
+	for name := range GameModes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckMaps determines if a mode can be played on every map in a rotation.
+func CheckMaps(mode GameMode, maps []*Map) error {
+	for _, gameMap := range maps {
+		if err := mode.CheckMap(gameMap); err != nil {
+			return fmt.Errorf("%s can not be played on %s: %v", mode.Name(), gameMap.Name, err)
+		}
+	}
+	return nil
+}
+
+// AddPlayer adds a player to the game and informs the game mode.
+func (game *Game) AddPlayer(player *Player) {
+	game.AddEntity(player)
+	if game.IsAuthoritative {
+		game.Mode.PlayerJoined(game, player)
+	}
+}
+
+// RemovePlayer informs the game mode that a player is leaving and removes
+// them from the game.
+func (game *Game) RemovePlayer(id uuid.UUID) {
+	player, ok := game.GetEntity(id).(*Player)
+	if ok && game.IsAuthoritative {
+		game.Mode.PlayerLeft(game, player)
+	}
+	game.RemoveEntity(id)
+}
+
+// RespawnPlayer moves a killed player to a spawn point with full health.
+func (game *Game) RespawnPlayer(player *Player, killerID uuid.UUID) {
+	player.Health = game.MaxHealth
+	player.Move(game.NextSpawnPoint(player.Team))
+	game.forgetPositions(player.ID())
+	change := PlayerRespawnChange{
+		Player:     player,
+		KilledByID: killerID,
+	}
+	game.sendChange(change)
+}
@@ -0,0 +1,116 @@
+package backend
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckMaps(t *testing.T) {
+	flags, err := LoadMap(filepath.Join("..", "..", "maps", "flags.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hill, err := LoadMap(filepath.Join("..", "..", "maps", "hill.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		mode  string
+		maps  []*Map
+		valid bool
+	}{
+		{mode: "deathmatch", maps: []*Map{MapDefault}, valid: true},
+		{mode: "ctf", maps: []*Map{flags}, valid: true},
+		{mode: "ctf", maps: []*Map{MapDefault}, valid: false},
+		{mode: "ctf", maps: []*Map{flags, MapDefault}, valid: false},
+		{mode: "koth", maps: []*Map{hill}, valid: true},
+		{mode: "koth", maps: []*Map{MapDefault}, valid: false},
+	}
+	for _, test := range tests {
+		err := CheckMaps(GameModes[test.mode](), test.maps)
+		if test.valid && err != nil {
+			t.Errorf("%s: expected maps to be valid, got %v", test.mode, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected maps to be invalid", test.mode)
+		}
+	}
+}
+
+func TestHillUsesGameCoordinates(t *testing.T) {
+	gameMap, err := ParseMap(strings.NewReader("hill: 8 3 1 1\n---\n" + openGrid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gameMap.Hills[0].Position != (Coordinate{X: 7, Y: 2}) {
+		t.Errorf("expected the map hill at column 7, row 2, got %+v", gameMap.Hills[0].Position)
+	}
+	game, _ := newTestGame(t, openGrid)
+	game.SetMap(gameMap)
+	mode := &KingOfTheHillMode{}
+	game.Mode = mode
+	mode.RoundStarted(game)
+	if game.Hill == nil || game.Hill.Position != (Coordinate{X: 0, Y: 0}) {
+		t.Fatalf("expected the active hill at 0,0, got %+v", game.Hill)
+	}
+	player := addTestPlayer(game, "king", Coordinate{X: 0, Y: 0})
+	if game.HillKing() != player {
+		t.Errorf("expected the player in the center of the map to hold the hill")
+	}
+}
+
+func TestEliminationEndsWhenOpponentsLeave(t *testing.T) {
+	game, _ := newTestGame(t, openGrid)
+	mode := &EliminationMode{}
+	game.Mode = mode
+	winner := addTestPlayer(game, "winner", Coordinate{X: -6, Y: 0})
+	if _, _, over := mode.CheckWinCondition(game); over {
+		t.Fatalf("expected a player waiting alone not to win")
+	}
+	first := addTestPlayer(game, "first", Coordinate{X: 0, Y: 0})
+	second := addTestPlayer(game, "second", Coordinate{X: 6, Y: 0})
+	if _, _, over := mode.CheckWinCondition(game); over {
+		t.Fatalf("expected the round to continue with three players alive")
+	}
+
+	game.RemovePlayer(first.ID())
+	if _, _, over := mode.CheckWinCondition(game); over {
+		t.Fatalf("expected the round to continue with two players alive")
+	}
+	game.RemovePlayer(second.ID())
+	winnerID, _, over := mode.CheckWinCondition(game)
+	if !over || winnerID != winner.ID() {
+		t.Errorf("expected the last player in the game to win, got %v %v", winnerID, over)
+	}
+}
+
+func TestTeamPlacements(t *testing.T) {
+	for _, name := range []string{"teamdeathmatch", "ctf"} {
+		game, _ := newTestGame(t, openGrid)
+		game.Mode = GameModes[name]()
+		red := addTestPlayer(game, "red", Coordinate{X: -6, Y: 0})
+		red.Team = TeamRed
+		carried := addTestPlayer(game, "carried", Coordinate{X: -5, Y: 0})
+		carried.Team = TeamRed
+		blue := addTestPlayer(game, "blue", Coordinate{X: 6, Y: 0})
+		blue.Team = TeamBlue
+		// The blue player has the best individual score, but their team
+		// lost.
+		game.Score[red.ID()] = 4
+		game.Score[blue.ID()] = 3
+
+		placements := game.Mode.Placements(game)
+		if len(placements) != 2 || len(placements[0]) != 2 || len(placements[1]) != 1 {
+			t.Fatalf("%s: expected the red team tied in first place, got %v", name, placements)
+		}
+		if placements[1][0] != blue.ID() {
+			t.Errorf("%s: expected the blue player in second place", name)
+		}
+
+		game.Score[blue.ID()] = 4
+		if placements := game.Mode.Placements(game); len(placements) != 1 {
+			t.Errorf("%s: expected teams with the same score to tie, got %v", name, placements)
+		}
+	}
+}
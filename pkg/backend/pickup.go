@@ -0,0 +1,167 @@
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	pickupRespawnTime    = 15 * time.Second
+	pickupEffectDuration = 10 * time.Second
+)
+
+// PickupKind describes the effect a pickup has on players.
+type PickupKind int
+
+// Contains pickup kind constants.
+const (
+	PickupHealth PickupKind = iota
+	PickupSpeedBoost
+	PickupRapidFire
+	PickupShield
+)
+
+// pickupMapTypes maps the map types where pickups spawn to the kind of pickup.
+var pickupMapTypes = map[MapType]PickupKind{
+	MapTypeHealth:     PickupHealth,
+	MapTypeSpeedBoost: PickupSpeedBoost,
+	MapTypeRapidFire:  PickupRapidFire,
+	MapTypeShield:     PickupShield,
+}
+
+// Pickup is an entity that gives players an effect when they walk over it.
+type Pickup struct {
+	IdentifierBase
+	Positioner
+	Kind            PickupKind
+	CurrentPosition Coordinate
+}
+
+// Position determines the pickup position.
+func (pickup *Pickup) Position() Coordinate {
+	return pickup.CurrentPosition
+}
+
+// pickupSpawner tracks a point on the map where pickups spawn.
+type pickupSpawner struct {
+	kind      PickupKind
+	position  Coordinate
+	pickupID  uuid.UUID
+	respawnAt time.Time
+}
+
+// updatePickups spawns pickups on the map once their respawn time has passed.
+// Pickups from previous maps are removed when the map changes.
+func (game *Game) updatePickups() {
+	if game.pickupMap != game.gameMap {
+		for _, entity := range game.Entities {
+			pickup, ok := entity.(*Pickup)
+			if !ok {
+				continue
+			}
+			game.sendChange(RemoveEntityChange{
+				Entity: pickup,
+			})
+			game.RemoveEntity(pickup.ID())
+		}
+		game.pickupSpawners = make([]*pickupSpawner, 0)
+		for mapType, positions := range game.GetMapByType() {
+			kind, ok := pickupMapTypes[mapType]
+			if !ok {
+				continue
+			}
+			for _, position := range positions {
+				game.pickupSpawners = append(game.pickupSpawners, &pickupSpawner{
+					kind:     kind,
+					position: position,
+				})
+			}
+		}
+		game.pickupMap = game.gameMap
+	}
+	now := game.Clock.Now()
+	for _, spawner := range game.pickupSpawners {
+		if spawner.pickupID != uuid.Nil || now.Before(spawner.respawnAt) {
+			continue
+		}
+		pickup := &Pickup{
+			IdentifierBase:  IdentifierBase{uuid.New()},
+			Kind:            spawner.kind,
+			CurrentPosition: spawner.position,
+		}
+		spawner.pickupID = pickup.ID()
+		game.AddEntity(pickup)
+		game.sendChange(AddEntityChange{
+			Entity: pickup,
+		})
+	}
+}
+
+// checkPickups gives pickups to players who are standing on them.
+func (game *Game) checkPickups() {
+	for _, entities := range game.getCollisionMap() {
+		if len(entities) <= 1 {
+			continue
+		}
+		var pickup *Pickup
+		var player *Player
+		for _, entity := range entities {
+			switch entity.(type) {
+			case *Pickup:
+				pickup = entity.(*Pickup)
+			case *Player:
+				if entity.(*Player).IsAlive() {
+					player = entity.(*Player)
+				}
+			}
+		}
+		if pickup == nil || player == nil {
+			continue
+		}
+		game.collectPickup(player, pickup)
+	}
+}
+
+// collectPickup applies the effect of a pickup to a player and queues the
+// pickup to respawn.
+func (game *Game) collectPickup(player *Player, pickup *Pickup) {
+	now := game.Clock.Now()
+	switch pickup.Kind {
+	case PickupHealth:
+		player.Health = game.MaxHealth
+	default:
+		if player.Effects == nil {
+			player.Effects = make(map[PickupKind]time.Time)
+		}
+		player.Effects[pickup.Kind] = now.Add(pickupEffectDuration)
+	}
+	for _, spawner := range game.pickupSpawners {
+		if spawner.pickupID == pickup.ID() {
+			spawner.pickupID = uuid.Nil
+			spawner.respawnAt = now.Add(pickupRespawnTime)
+		}
+	}
+	game.RemoveEntity(pickup.ID())
+	change := PickupChange{
+		Player: player,
+		Pickup: pickup,
+	}
+	game.sendChange(change)
+}
+
+// String returns the name of a pickup kind.
+func (kind PickupKind) String() string {
+	switch kind {
+	case PickupHealth:
+		return "Health"
+	case PickupSpeedBoost:
+		return "Speed boost"
+	case PickupRapidFire:
+		return "Rapid fire"
+	case PickupShield:
+		return "Shield"
+	}
+	return fmt.Sprintf("PickupKind(%d)", int(kind))
+}
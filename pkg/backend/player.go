@@ -1,5 +1,17 @@
 package backend
 
+import "time"
+
+// PlayerState describes if a player is playing or waiting for the next round.
+type PlayerState int
+
+// Contains player state constants.
+const (
+	PlayerAlive PlayerState = iota
+	// PlayerDead players spectate until the round is over.
+	PlayerDead
+)
+
 // Player contains information unique to local and remote players.
 type Player struct {
 	IdentifierBase
@@ -8,6 +20,11 @@ type Player struct {
 	CurrentPosition Coordinate
 	Name            string
 	Icon            rune
+	Health          int
+	Weapon          WeaponType
+	Effects         map[PickupKind]time.Time
+	Team            Team
+	State           PlayerState
 }
 
 // Position determines the player position.
@@ -19,3 +36,14 @@ func (p *Player) Position() Coordinate {
 func (p *Player) Move(c Coordinate) {
 	p.CurrentPosition = c
 }
+
+// HasEffect checks if a pickup effect is active for the player.
+func (p *Player) HasEffect(kind PickupKind, now time.Time) bool {
+	expiresAt, ok := p.Effects[kind]
+	return ok && now.Before(expiresAt)
+}
+
+// IsAlive determines if the player is playing, rather than spectating.
+func (p *Player) IsAlive() bool {
+	return p.State == PlayerAlive
+}
@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// positionSnapshot is the position of every player at the end of a tick.
+type positionSnapshot struct {
+	time      time.Time
+	positions map[uuid.UUID]Coordinate
+}
+
+// recordPositions remembers where players are at the end of the current
+// tick, and forgets snapshots that are too old to rewind to.
+func (game *Game) recordPositions() {
+	now := game.Clock.Now()
+	snapshot := positionSnapshot{
+		time:      now,
+		positions: make(map[uuid.UUID]Coordinate),
+	}
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if ok {
+			snapshot.positions[player.ID()] = player.Position()
+		}
+	}
+	game.positionHistory = append(game.positionHistory, snapshot)
+	// Keep one snapshot older than the rewind window, so that rewinding the
+	// full window still finds a position.
+	oldest := now.Add(-game.MaxRewind)
+	expired := 0
+	for expired+1 < len(game.positionHistory) && !game.positionHistory[expired+1].time.After(oldest) {
+		expired++
+	}
+	game.positionHistory = game.positionHistory[expired:]
+}
+
+// forgetPositions removes a player from the position history, so that they
+// can not be hit where they were before teleporting, i.e. after respawning.
+func (game *Game) forgetPositions(playerID uuid.UUID) {
+	for _, snapshot := range game.positionHistory {
+		delete(snapshot.positions, playerID)
+	}
+}
+
+// rewindFor determines how far back in time laser hits should be checked for
+// a shooter who was seeing the game as of viewTime. The rewind is limited to
+// MaxRewind, so that players with very high latency can not hit players who
+// have long since moved on.
+func (game *Game) rewindFor(viewTime time.Time) time.Duration {
+	if viewTime.IsZero() {
+		return 0
+	}
+	rewind := game.Clock.Now().Sub(viewTime)
+	if rewind < 0 {
+		return 0
+	}
+	if rewind > game.MaxRewind {
+		return game.MaxRewind
+	}
+	return rewind
+}
+
+// playerPositionAt determines where a player was at the given time, using the
+// latest snapshot taken at or before it. Players that are not in the history
+// are assumed to be where they are now.
+func (game *Game) playerPositionAt(player *Player, at time.Time) Coordinate {
+	for i := len(game.positionHistory) - 1; i >= 0; i-- {
+		snapshot := game.positionHistory[i]
+		if snapshot.time.After(at) {
+			continue
+		}
+		position, ok := snapshot.positions[player.ID()]
+		if ok {
+			return position
+		}
+		break
+	}
+	return player.Position()
+}
+
+// playersAt finds the living players that were at a position, rewind ago.
+func (game *Game) playersAt(position Coordinate, rewind time.Duration) []Identifier {
+	at := game.Clock.Now().Add(-rewind)
+	players := make([]Identifier, 0)
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if !ok || !player.IsAlive() {
+			continue
+		}
+		playerPosition := player.Position()
+		if rewind > 0 {
+			playerPosition = game.playerPositionAt(player, at)
+		}
+		if playerPosition == position {
+			players = append(players, player)
+		}
+	}
+	return players
+}
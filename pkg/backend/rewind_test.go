@@ -0,0 +1,78 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestLaserHitsRewoundPosition(t *testing.T) {
+	game, clock := newTestGame(t, openGrid)
+	tick := time.Second / DefaultTickRate
+	step := func(duration time.Duration) {
+		for elapsed := time.Duration(0); elapsed < duration; elapsed += tick {
+			game.Step()
+			clock.Advance(tick)
+		}
+	}
+	target := addTestPlayer(game, "target", Coordinate{X: 1, Y: 0})
+	hitter := addTestPlayer(game, "hitter", Coordinate{X: -2, Y: 0})
+	misser := addTestPlayer(game, "misser", Coordinate{X: -2, Y: -1})
+	step(500 * time.Millisecond)
+	subscription := game.ChangeBus.Subscribe(1000, OverflowDisconnect)
+	defer subscription.Unsubscribe()
+
+	// The target moves out of the way, but the shooters are seeing the game
+	// as it was a little while ago.
+	viewTime := clock.Now().Add(-150 * time.Millisecond)
+	target.Move(Coordinate{X: 1, Y: -1})
+	step(tick)
+	for _, shooter := range []*Player{hitter, misser} {
+		game.actionQueue = append(game.actionQueue, LaserAction{
+			Direction: DirectionRight,
+			ID:        uuid.New(),
+			OwnerID:   shooter.ID(),
+			Created:   clock.Now(),
+			ViewTime:  viewTime,
+		})
+	}
+	step(time.Second)
+
+	damagedBy := make([]uuid.UUID, 0)
+	for len(subscription.Changes) > 0 {
+		change, ok := (<-subscription.Changes).(PlayerDamageChange)
+		if ok && change.Player == target {
+			damagedBy = append(damagedBy, change.DamagedByID)
+		}
+	}
+	if len(damagedBy) != 1 || damagedBy[0] != hitter.ID() {
+		t.Errorf("expected only the laser aimed at the rewound position to hit, got hits from %v", damagedBy)
+	}
+}
+
+func TestPositionHistoryIsPruned(t *testing.T) {
+	game, clock := newTestGame(t, openGrid)
+	addTestPlayer(game, "player", Coordinate{X: 0, Y: 0})
+	tick := time.Second / DefaultTickRate
+	for i := 0; i < DefaultTickRate*2; i++ {
+		game.Step()
+		clock.Advance(tick)
+	}
+	game.Step()
+
+	oldest := clock.Now().Add(-game.MaxRewind)
+	history := game.positionHistory
+	if len(history) < 2 {
+		t.Fatalf("expected more than one snapshot, got %d", len(history))
+	}
+	if history[0].time.After(oldest) {
+		t.Errorf("expected one snapshot older than the rewind window to be kept")
+	}
+	if !history[1].time.After(oldest) {
+		t.Errorf("expected snapshots older than the rewind window to be pruned, second snapshot is from %s", history[1].time)
+	}
+	if game.rewindFor(clock.Now().Add(-time.Hour)) != game.MaxRewind {
+		t.Errorf("expected rewinds to be limited to MaxRewind")
+	}
+}
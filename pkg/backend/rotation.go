@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"math/rand"
+	"time"
+)
+
+// MapRotation chooses the map used for each round, either in order or
+// shuffled.
+type MapRotation struct {
+	maps    []*Map
+	shuffle bool
+	order   []int
+	index   int
+	random  *rand.Rand
+}
+
+// NewMapRotation constructs a new MapRotation struct.
+func NewMapRotation(maps []*Map, shuffle bool) *MapRotation {
+	return &MapRotation{
+		maps:    maps,
+		shuffle: shuffle,
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Next returns the map that should be used for the next round. When shuffling,
+// every map is played once before the order is shuffled again.
+func (rotation *MapRotation) Next() *Map {
+	if rotation.index >= len(rotation.order) {
+		rotation.index = 0
+		previous := -1
+		if len(rotation.order) > 0 {
+			previous = rotation.order[len(rotation.order)-1]
+		}
+		rotation.order = make([]int, len(rotation.maps))
+		for i := range rotation.order {
+			rotation.order[i] = i
+		}
+		if rotation.shuffle {
+			rotation.random.Shuffle(len(rotation.order), func(i, j int) {
+				rotation.order[i], rotation.order[j] = rotation.order[j], rotation.order[i]
+			})
+			// Avoid playing the same map twice in a row.
+			if len(rotation.order) > 1 && rotation.order[0] == previous {
+				rotation.order[0], rotation.order[1] = rotation.order[1], rotation.order[0]
+			}
+		}
+	}
+	gameMap := rotation.maps[rotation.order[rotation.index]]
+	rotation.index++
+	return gameMap
+}
@@ -0,0 +1,83 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Team is used to represent team constants.
+type Team int
+
+// Contains team constants - TeamNone is used when teams are disabled.
+const (
+	TeamNone Team = iota
+	TeamRed
+	TeamBlue
+)
+
+// Teams contains every team players can be assigned to.
+var Teams = []Team{TeamRed, TeamBlue}
+
+// spawnMapTypes maps spawn point map types to the team that can use them.
+var spawnMapTypes = map[MapType]Team{
+	MapTypeSpawn:     TeamNone,
+	MapTypeSpawnRed:  TeamRed,
+	MapTypeSpawnBlue: TeamBlue,
+}
+
+// String returns the name of a team.
+func (team Team) String() string {
+	switch team {
+	case TeamNone:
+		return "None"
+	case TeamRed:
+		return "Red"
+	case TeamBlue:
+		return "Blue"
+	}
+	return fmt.Sprintf("Team(%d)", int(team))
+}
+
+// AssignTeam returns the team a new player should join, which is the team
+// with the fewest players. TeamNone is returned if teams are disabled.
+func (game *Game) AssignTeam() Team {
+	if !game.Mode.Teams() {
+		return TeamNone
+	}
+	counts := make(map[Team]int)
+	for _, entity := range game.Entities {
+		player, ok := entity.(*Player)
+		if ok {
+			counts[player.Team]++
+		}
+	}
+	assigned := Teams[0]
+	for _, team := range Teams {
+		if counts[team] < counts[assigned] {
+			assigned = team
+		}
+	}
+	return assigned
+}
+
+// TeamScore returns the sum of the scores of every player on a team.
+func (game *Game) TeamScore(team Team) int {
+	score := 0
+	for id, playerScore := range game.Score {
+		player, ok := game.GetEntity(id).(*Player)
+		if ok && player.Team == team {
+			score += playerScore
+		}
+	}
+	return score
+}
+
+// isFriendlyFire checks if a laser fired by one player hit a teammate.
+func (game *Game) isFriendlyFire(ownerID uuid.UUID, player *Player) bool {
+	if !game.Mode.Teams() || game.FriendlyFire || player.Team == TeamNone {
+		return false
+	}
+	owner, ok := game.GetEntity(ownerID).(*Player)
+	return ok && owner.Team == player.Team
+}
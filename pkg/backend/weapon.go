@@ -0,0 +1,95 @@
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WeaponType identifies a kind of weapon.
+type WeaponType int
+
+// Contains weapon type constants - WeaponLaser is used by default.
+const (
+	WeaponLaser WeaponType = iota
+	WeaponShotgun
+	WeaponHeavy
+	WeaponSniper
+)
+
+// Weapon describes the lasers fired by a weapon.
+type Weapon struct {
+	Name string
+	// Speed is the time it takes a laser to move one tile.
+	Speed time.Duration
+	// Cooldown is the minimum time between shots.
+	Cooldown time.Duration
+	Damage   int
+	// Range is the number of tiles a laser can travel, or zero for no limit.
+	Range int
+	// Spread is the number of lasers fired side by side with each shot.
+	Spread int
+}
+
+// Weapons contains the definition of every weapon type.
+var Weapons = map[WeaponType]Weapon{
+	WeaponLaser: {
+		Name:     "Laser",
+		Speed:    50 * time.Millisecond,
+		Cooldown: 500 * time.Millisecond,
+		Damage:   1,
+		Spread:   1,
+	},
+	WeaponShotgun: {
+		Name:     "Shotgun",
+		Speed:    60 * time.Millisecond,
+		Cooldown: 900 * time.Millisecond,
+		Damage:   1,
+		Range:    8,
+		Spread:   3,
+	},
+	WeaponHeavy: {
+		Name:     "Heavy bolt",
+		Speed:    150 * time.Millisecond,
+		Cooldown: 1200 * time.Millisecond,
+		Damage:   3,
+		Spread:   1,
+	},
+	WeaponSniper: {
+		Name:     "Sniper",
+		Speed:    15 * time.Millisecond,
+		Cooldown: 2000 * time.Millisecond,
+		Damage:   2,
+		Spread:   1,
+	},
+}
+
+// SwitchWeaponAction is sent when a player changes their weapon.
+type SwitchWeaponAction struct {
+	ID      uuid.UUID
+	Weapon  WeaponType
+	Created time.Time
+}
+
+// Perform changes the weapon of a player.
+func (action SwitchWeaponAction) Perform(game *Game) {
+	player, ok := game.GetEntity(action.ID).(*Player)
+	if !ok {
+		return
+	}
+	if _, ok := Weapons[action.Weapon]; !ok {
+		return
+	}
+	actionKey := fmt.Sprintf("%T:%s", action, player.ID().String())
+	if !game.checkLastActionTime(actionKey, action.Created, switchWeaponThrottle) {
+		return
+	}
+	player.Weapon = action.Weapon
+	change := WeaponChange{
+		Player: player,
+		Weapon: action.Weapon,
+	}
+	game.sendChange(change)
+	game.updateLastActionTime(actionKey, action.Created)
+}
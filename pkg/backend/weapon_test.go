@@ -0,0 +1,115 @@
+package backend
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+const weaponGrid = "" +
+	"█████████████████████████\n" +
+	"█S                     S█\n" +
+	"█                       █\n" +
+	"█                       █\n" +
+	"█████████████████████████\n"
+
+func TestShotgunFiresSpread(t *testing.T) {
+	game, _ := newTestGame(t, weaponGrid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -10, Y: 0})
+	shooter.Weapon = WeaponShotgun
+
+	fire(game, shooter, DirectionRight)
+	game.Step()
+	rows := make([]int, 0)
+	for _, entity := range game.Entities {
+		laser, ok := entity.(*Laser)
+		if !ok {
+			continue
+		}
+		if laser.InitialPosition.X != -9 {
+			t.Errorf("expected the laser to start next to the shooter, got %v", laser.InitialPosition)
+		}
+		rows = append(rows, laser.InitialPosition.Y)
+	}
+	sort.Ints(rows)
+	if len(rows) != 3 || rows[0] != -1 || rows[1] != 0 || rows[2] != 1 {
+		t.Errorf("expected lasers side by side on rows -1 to 1, got %v", rows)
+	}
+}
+
+func TestWeaponRangeRemovesLasers(t *testing.T) {
+	game, clock := newTestGame(t, weaponGrid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -10, Y: 0})
+	shooter.Weapon = WeaponShotgun
+	weapon := Weapons[WeaponShotgun]
+
+	fire(game, shooter, DirectionRight)
+	game.Step()
+	clock.Advance(weapon.Speed * time.Duration(weapon.Range))
+	game.Step()
+	if lasers := countLasers(game); lasers != weapon.Spread {
+		t.Fatalf("expected lasers to travel %d tiles, found %d lasers", weapon.Range, lasers)
+	}
+	clock.Advance(weapon.Speed)
+	game.Step()
+	if lasers := countLasers(game); lasers != 0 {
+		t.Errorf("expected lasers to be removed past their range, found %d lasers", lasers)
+	}
+}
+
+func TestWeaponDamage(t *testing.T) {
+	game, clock := newTestGame(t, weaponGrid)
+	shooter := addTestPlayer(game, "shooter", Coordinate{X: -10, Y: 0})
+	target := addTestPlayer(game, "target", Coordinate{X: -5, Y: 0})
+
+	for _, weaponType := range []WeaponType{WeaponLaser, WeaponHeavy} {
+		shooter.Weapon = weaponType
+		target.Health = game.MaxHealth
+		clock.Advance(Weapons[weaponType].Cooldown)
+		fire(game, shooter, DirectionRight)
+		game.Step()
+		for i := 0; i < 5*DefaultTickRate && countLasers(game) > 0; i++ {
+			clock.Advance(time.Second / DefaultTickRate)
+			game.Step()
+		}
+		expected := game.MaxHealth - Weapons[weaponType].Damage
+		if expected <= 0 {
+			// Players who are killed respawn with full health.
+			expected = game.MaxHealth
+		}
+		if target.Health != expected {
+			t.Errorf("%s: expected target health to be %d, got %d", Weapons[weaponType].Name, expected, target.Health)
+		}
+	}
+	if game.Score[shooter.ID()] != 1 {
+		t.Errorf("expected the heavy bolt to kill the target, score is %d", game.Score[shooter.ID()])
+	}
+}
+
+func TestSwitchWeaponIsThrottled(t *testing.T) {
+	game, clock := newTestGame(t, weaponGrid)
+	player := addTestPlayer(game, "player", Coordinate{X: 0, Y: 0})
+	switchWeapon := func(weapon WeaponType) {
+		game.actionQueue = append(game.actionQueue, SwitchWeaponAction{
+			ID:      player.ID(),
+			Weapon:  weapon,
+			Created: clock.Now(),
+		})
+		game.Step()
+	}
+
+	switchWeapon(WeaponShotgun)
+	if player.Weapon != WeaponShotgun {
+		t.Fatalf("expected the player to switch to the shotgun, has %s", Weapons[player.Weapon].Name)
+	}
+	clock.Advance(switchWeaponThrottle - time.Millisecond)
+	switchWeapon(WeaponSniper)
+	if player.Weapon != WeaponShotgun {
+		t.Fatalf("expected the second switch to be throttled, has %s", Weapons[player.Weapon].Name)
+	}
+	clock.Advance(time.Millisecond)
+	switchWeapon(WeaponSniper)
+	if player.Weapon != WeaponSniper {
+		t.Errorf("expected the player to switch to the sniper, has %s", Weapons[player.Weapon].Name)
+	}
+}
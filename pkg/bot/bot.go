@@ -16,29 +16,35 @@ type bot struct {
 
 // Bots controls all bots added to a game.
 type Bots struct {
-	bots []*bot
-	game *backend.Game
+	bots    []*bot
+	game    *backend.Game
+	started bool
+	done    chan struct{}
+	stopped chan struct{}
 }
 
 // NewBots creates a new bots instance.
 func NewBots(game *backend.Game) *Bots {
 	return &Bots{
-		game: game,
-		bots: make([]*bot, 0),
+		game:    game,
+		bots:    make([]*bot, 0),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
 	}
 }
 
 // AddBot adds a new bot to the game.
 func (bots *Bots) AddBot(name string) *backend.Player {
 	playerID := uuid.New()
+	bots.game.Mu.Lock()
 	player := &backend.Player{
 		Name:            name,
 		Icon:            'b',
 		IdentifierBase:  backend.IdentifierBase{playerID},
-		CurrentPosition: backend.Coordinate{X: -1, Y: 9},
+		CurrentPosition: bots.game.NextSpawnPoint(backend.TeamNone),
+		Health:          bots.game.MaxHealth,
 	}
-	bots.game.Mu.Lock()
-	bots.game.AddEntity(player)
+	bots.game.AddPlayer(player)
 	bots.game.Mu.Unlock()
 	bots.bots = append(bots.bots, &bot{playerID: playerID})
 	return player
@@ -135,38 +141,58 @@ func getShootDirection(world *world, c1 backend.Coordinate, c2 backend.Coordinat
 	return direction
 }
 
-// Start starts the goroutine used to determine bot moves.
-func (bots *Bots) Start() {
-	go func() {
-		world := &world{
-			tiles: make(map[backend.Coordinate]*tile),
-		}
-		for symbol, positions := range bots.game.GetMapByType() {
-			for _, position := range positions {
-				if symbol == backend.MapTypeWall {
-					world.tiles[position] = &tile{
-						position: position,
-						world:    world,
-						kind:     tileWall,
-					}
-				} else {
-					world.tiles[position] = &tile{
-						position: position,
-						world:    world,
-						kind:     tileNone,
-					}
+// newWorld builds a world from the current game map.
+func newWorld(game *backend.Game) *world {
+	world := &world{
+		tiles: make(map[backend.Coordinate]*tile),
+	}
+	for symbol, positions := range game.GetMapByType() {
+		for _, position := range positions {
+			if symbol == backend.MapTypeWall {
+				world.tiles[position] = &tile{
+					position: position,
+					world:    world,
+					kind:     tileWall,
+				}
+			} else {
+				world.tiles[position] = &tile{
+					position: position,
+					world:    world,
+					kind:     tileNone,
 				}
 			}
 		}
+	}
+	return world
+}
+
+// Start starts the goroutine used to determine bot moves.
+func (bots *Bots) Start() {
+	bots.started = true
+	go func() {
+		defer close(bots.stopped)
+		var world *world
+		var worldMap *backend.Map
 		for {
 			bots.game.Mu.RLock()
+			// Rebuild the world when the map changes.
+			if gameMap := bots.game.GetMap(); gameMap != worldMap {
+				world = newWorld(bots.game)
+				worldMap = gameMap
+			}
 			// Get all player positions.
 			playerPositions := make(map[uuid.UUID]backend.Coordinate, 0)
+			playerTeams := make(map[uuid.UUID]backend.Team, 0)
 			for _, entity := range bots.game.Entities {
 				switch entity.(type) {
 				case *backend.Player:
 					player := entity.(*backend.Player)
+					// Ignore players who are spectating.
+					if !player.IsAlive() {
+						continue
+					}
 					playerPositions[entity.ID()] = player.Position()
+					playerTeams[entity.ID()] = player.Team
 				}
 			}
 			bots.game.Mu.RUnlock()
@@ -184,6 +210,10 @@ func (bots *Bots) Start() {
 					if id == player.ID() {
 						continue
 					}
+					// Ignore teammates.
+					if player.Team != backend.TeamNone && playerTeams[id] == player.Team {
+						continue
+					}
 					// Check if we're on top of the player and move if so.
 					if position == playerPosition {
 						closestPosition = position.Add(backend.Coordinate{
@@ -222,7 +252,7 @@ func (bots *Bots) Start() {
 						ID:        uuid.New(),
 						OwnerID:   player.ID(),
 						Direction: shootDirection,
-						Created:   time.Now(),
+						Created:   bots.game.Clock.Now(),
 					}
 					continue
 				}
@@ -269,10 +299,24 @@ func (bots *Bots) Start() {
 				bots.game.ActionChannel <- backend.MoveAction{
 					ID:        player.ID(),
 					Direction: direction,
-					Created:   time.Now(),
+					Created:   bots.game.Clock.Now(),
 				}
 			}
-			time.Sleep(time.Millisecond * 200)
+			select {
+			case <-time.After(time.Millisecond * 200):
+			case <-bots.done:
+				return
+			}
 		}
 	}()
 }
+
+// Stop stops the bots and waits for them to finish their last moves. This
+// must be called before the game is stopped, as bots wait for the game to
+// accept their actions.
+func (bots *Bots) Stop() {
+	close(bots.done)
+	if bots.started {
+		<-bots.stopped
+	}
+}
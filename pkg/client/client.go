@@ -2,8 +2,11 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
@@ -14,65 +17,412 @@ import (
 )
 
 const (
-	positionHistoryLimit = 5
+	changeQueueSize = 256
+	// defaultInterpolationDelay is how far behind the server remote players
+	// are displayed, which smooths out their movement.
+	defaultInterpolationDelay = 100 * time.Millisecond
+	interpolationFrequency    = 10 * time.Millisecond
+	// resumeTimeout should match how long the server waits for clients to
+	// resume.
+	resumeTimeout       = 30 * time.Second
+	resumeRetryInterval = time.Second
+	resyncThrottle      = time.Second
 )
 
 // GameClient is used to stream game information to a server and update the
 // game state as needed.
 type GameClient struct {
-	CurrentPlayer   uuid.UUID
-	Stream          proto.Game_StreamClient
-	Game            *backend.Game
-	View            *frontend.View
-	positionHistory []backend.Coordinate
+	CurrentPlayer uuid.UUID
+	// Room is the room to join. The server's default room is used if empty.
+	Room         string
+	Stream       proto.Game_StreamClient
+	Game         *backend.Game
+	View         *frontend.View
+	grpcClient   proto.GameClient
+	token        string
+	streamMu     sync.Mutex
+	snapshotTick uint64
+	lastResync   time.Time
+	// requestSequence is the sequence of the last request sent on the
+	// stream, and responseSequence the last response received.
+	requestSequence  uint64
+	responseSequence uint64
+	// serverTick is the server's game tick as of the last response.
+	serverTick uint64
+	// ackedRequest is the sequence of the last request the server has
+	// processed.
+	ackedRequest uint64
+	// pendingMoves are moves that have been predicted locally, but not yet
+	// acknowledged by the server.
+	pendingMoves []pendingMove
+	interpolator *interpolator
+	// lobby is the last lobby state sent by the server, or nil if the match
+	// had already started when we connected.
+	lobby *proto.LobbyState
+	// OnLobbyState is called when the lobby changes. It is called while the
+	// game lock is held and should not block.
+	OnLobbyState func(*proto.LobbyState)
+}
+
+// pendingMove is a move request that the server has not processed yet.
+type pendingMove struct {
+	sequence  uint64
+	direction backend.Direction
 }
 
 // NewGameClient constructs a new game client struct.
 func NewGameClient(game *backend.Game, view *frontend.View) *GameClient {
 	return &GameClient{
-		Game:            game,
-		View:            view,
-		positionHistory: make([]backend.Coordinate, positionHistoryLimit),
+		Game:         game,
+		View:         view,
+		interpolator: newInterpolator(game.Clock, defaultInterpolationDelay),
 	}
 }
 
+// SetInterpolationDelay sets how far behind the server remote players are
+// displayed. Longer delays hide more network jitter, but show players where
+// they were longer ago.
+func (c *GameClient) SetInterpolationDelay(delay time.Duration) {
+	c.Game.Mu.Lock()
+	c.interpolator.delay = delay
+	c.Game.Mu.Unlock()
+}
+
 // Connect connects a new player to the server.
-func (c *GameClient) Connect(grpcClient proto.GameClient, playerID uuid.UUID, playerName string, password string) error {
-	// Connect to server.
+// The team is only used if the server has teams enabled, and TeamNone lets
+// the server choose a team.
+func (c *GameClient) Connect(grpcClient proto.GameClient, playerID uuid.UUID, playerName string, password string, team backend.Team) error {
 	req := proto.ConnectRequest{
 		Id:       playerID.String(),
 		Name:     playerName,
 		Password: password,
+		Team:     proto.GetProtoTeam(team),
+		Room:     c.Room,
 	}
-	resp, err := grpcClient.Connect(context.Background(), &req)
+	err := c.connect(grpcClient, &req)
 	if err != nil {
 		return err
 	}
+	c.CurrentPlayer = playerID
+	c.View.CurrentPlayer = playerID
+	return nil
+}
 
-	// Add initial entity state.
-	for _, entity := range resp.Entities {
-		backendEntity := proto.GetBackendEntity(entity)
-		if backendEntity == nil {
-			return fmt.Errorf("can not get backend entity from %+v", entity)
+// Matchmake waits for the server to match us with players of a similar
+// rating, and sets the room to the match's room. The response contains the
+// password and team to connect with. An empty mode uses the server's default
+// mode, and a size of zero the server's default match size.
+func (c *GameClient) Matchmake(grpcClient proto.GameClient, playerName string, mode string, size int) (*proto.MatchmakeResponse, error) {
+	resp, err := grpcClient.Matchmake(context.Background(), &proto.MatchmakeRequest{
+		Name: playerName,
+		Mode: mode,
+		Size: int32(size),
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.Room = resp.Room
+	return resp, nil
+}
+
+// leaderboard fetches the server's leaderboard for the view.
+func (c *GameClient) leaderboard() ([]frontend.LeaderboardEntry, error) {
+	resp, err := c.grpcClient.Leaderboard(context.Background(), &proto.LeaderboardRequest{})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]frontend.LeaderboardEntry, 0)
+	for _, entry := range resp.Entries {
+		entries = append(entries, frontend.LeaderboardEntry{
+			Rank:   int(entry.Rank),
+			Name:   entry.Name,
+			Rating: int(entry.Rating),
+			Rounds: int(entry.Rounds),
+			Wins:   int(entry.Wins),
+		})
+	}
+	return entries, nil
+}
+
+// Spectate connects to the server as a spectator, which receives game state
+// without controlling a player.
+func (c *GameClient) Spectate(grpcClient proto.GameClient, password string) error {
+	req := proto.ConnectRequest{
+		Password:  password,
+		Spectator: true,
+		Room:      c.Room,
+	}
+	err := c.connect(grpcClient, &req)
+	if err != nil {
+		return err
+	}
+	c.View.Spectator = true
+	return nil
+}
+
+// connect sends a connect request, loads the initial game state, and opens
+// the stream.
+func (c *GameClient) connect(grpcClient proto.GameClient, req *proto.ConnectRequest) error {
+	resp, err := grpcClient.Connect(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	c.grpcClient = grpcClient
+	c.token = resp.Token
+	c.View.Leaderboard = c.leaderboard
+	err = c.loadConnectResponse(resp)
+	if err != nil {
+		return err
+	}
+	return c.openStream()
+}
+
+// Leave tells the server that we are quitting, so that our player is removed
+// right away instead of waiting for us to resume.
+func (c *GameClient) Leave() error {
+	_, err := c.grpcClient.Leave(context.Background(), &proto.LeaveRequest{
+		Token: c.token,
+	})
+	return err
+}
+
+// Lobby returns the last lobby state sent by the server, or nil if the match
+// had already started when we connected.
+func (c *GameClient) Lobby() *proto.LobbyState {
+	c.Game.Mu.RLock()
+	defer c.Game.Mu.RUnlock()
+	return c.lobby
+}
+
+// InLobby checks if we are waiting in the lobby for the match to start.
+func (c *GameClient) InLobby() bool {
+	lobby := c.Lobby()
+	return lobby != nil && !lobby.Started
+}
+
+// UpdateLobby changes our choices in the lobby. The team is only used if the
+// game mode has teams, and an empty icon keeps the current icon.
+func (c *GameClient) UpdateLobby(team backend.Team, icon string, ready bool) {
+	c.send(&proto.Request{
+		Action: &proto.Request_UpdateLobby{
+			UpdateLobby: &proto.UpdateLobby{
+				Team:  proto.GetProtoTeam(team),
+				Icon:  icon,
+				Ready: ready,
+			},
+		},
+	})
+}
+
+// StartMatch asks the server to start the match. Only the lobby's host can
+// start the match before everyone is ready.
+func (c *GameClient) StartMatch() {
+	c.send(&proto.Request{
+		Action: &proto.Request_StartMatch{
+			StartMatch: &proto.StartMatch{},
+		},
+	})
+}
+
+// resume reconnects to the server after the stream breaks, and replaces the
+// game state with the server's. Attempts are retried until resumeTimeout has
+// passed.
+func (c *GameClient) resume() error {
+	var err error
+	deadline := time.Now().Add(resumeTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(resumeRetryInterval)
+		var resp *proto.ConnectResponse
+		resp, err = c.grpcClient.Resume(context.Background(), &proto.ResumeRequest{
+			Token: c.token,
+		})
+		if err != nil {
+			continue
+		}
+		err = c.loadConnectResponse(resp)
+		if err != nil {
+			return err
+		}
+		err = c.openStream()
+		if err == nil {
+			return nil
 		}
-		c.Game.AddEntity(backendEntity)
 	}
+	return err
+}
 
-	// Initialize stream with token.
-	header := metadata.New(map[string]string{"authorization": resp.Token})
+// openStream opens a new stream with the client's token.
+func (c *GameClient) openStream() error {
+	header := metadata.New(map[string]string{"authorization": c.token})
 	ctx := metadata.NewOutgoingContext(context.Background(), header)
-	stream, err := grpcClient.Stream(ctx)
+	stream, err := c.grpcClient.Stream(ctx)
 	if err != nil {
 		return err
 	}
-
-	c.CurrentPlayer = playerID
-	c.View.CurrentPlayer = playerID
+	c.streamMu.Lock()
 	c.Stream = stream
+	// Sequences start over for every stream.
+	c.requestSequence = 0
+	c.responseSequence = 0
+	c.streamMu.Unlock()
+	c.Game.Mu.Lock()
+	c.pendingMoves = nil
+	c.Game.Mu.Unlock()
+	return nil
+}
+
+// send sends a request to the server. Requests sent while resuming are lost.
+func (c *GameClient) send(req *proto.Request) {
+	c.streamMu.Lock()
+	defer c.streamMu.Unlock()
+	c.requestSequence++
+	req.Sequence = c.requestSequence
+	c.Stream.Send(req)
+}
+
+// checkSequence determines if a response should be handled. Responses that
+// arrive out of order are ignored, and a resync is requested if responses
+// were missed. The game lock must be held by the caller.
+func (c *GameClient) checkSequence(resp *proto.Response) bool {
+	c.streamMu.Lock()
+	lastSequence := c.responseSequence
+	if resp.Sequence > lastSequence {
+		c.responseSequence = resp.Sequence
+	}
+	c.streamMu.Unlock()
+	if resp.Sequence <= lastSequence {
+		log.Printf("ignoring out of order response %d", resp.Sequence)
+		return false
+	}
+	if resp.Sequence > lastSequence+1 {
+		log.Printf("missed %d responses", resp.Sequence-lastSequence-1)
+		c.requestResync()
+	}
+	if resp.Tick > c.serverTick {
+		c.serverTick = resp.Tick
+	}
+	return true
+}
+
+// loadConnectResponse replaces the game state with the state sent when
+// connecting or resuming.
+func (c *GameClient) loadConnectResponse(resp *proto.ConnectResponse) error {
+	// Use the same map as the server.
+	if resp.Map == nil {
+		return errors.New("server did not provide a map")
+	}
+	c.Game.Mu.Lock()
+	defer c.Game.Mu.Unlock()
+	// Snapshots from a previous session are no longer relevant.
+	c.snapshotTick = 0
+	c.serverTick = resp.Tick
+	c.ackedRequest = 0
+	c.pendingMoves = nil
+	c.interpolator.reset()
+	c.lobby = resp.Lobby
+	return c.loadSnapshot(&proto.Snapshot{
+		Tick:      resp.Tick,
+		Entities:  resp.Entities,
+		Scores:    resp.Scores,
+		RoundOver: resp.RoundOver,
+		Map:       resp.Map,
+		Hill:      resp.Hill,
+	}, time.Time{})
+}
 
+// loadSnapshot replaces the game state with a snapshot of the server's state.
+// Snapshots older than the last one are ignored, and a resync is requested if
+// the snapshot is for a different map. If the server time of the snapshot is
+// given, remote players are moved to their snapshot positions when they are
+// due, like any other position update. The game lock must be held by the
+// caller.
+func (c *GameClient) loadSnapshot(snapshot *proto.Snapshot, serverTime time.Time) error {
+	if snapshot.Tick < c.snapshotTick {
+		return nil
+	}
+	gameMap := c.Game.GetMap()
+	if snapshot.Map != nil {
+		gameMap = proto.GetBackendMap(snapshot.Map)
+		if gameMap == nil {
+			return fmt.Errorf("can not get backend map from %q", snapshot.Map.Name)
+		}
+	} else if snapshot.MapId != gameMap.ID() {
+		c.requestResync()
+		return nil
+	}
+	entities := make([]backend.Identifier, 0)
+	for _, entity := range snapshot.Entities {
+		backendEntity := proto.GetBackendEntity(entity)
+		if backendEntity == nil {
+			return fmt.Errorf("can not get backend entity from %+v", entity)
+		}
+		entities = append(entities, backendEntity)
+	}
+	scores := make(map[uuid.UUID]int)
+	for id, score := range snapshot.Scores {
+		playerID, err := uuid.Parse(id)
+		if err != nil {
+			return err
+		}
+		scores[playerID] = int(score)
+	}
+
+	// Keep our own position if we have moves the server has not processed
+	// yet, to prevent jittering. The next acknowledgement corrects it.
+	currentPlayer, hasCurrentPlayer := c.Game.GetEntity(c.CurrentPlayer).(*backend.Player)
+	// Positions can only be interpolated on the same map.
+	interpolate := !serverTime.IsZero() && gameMap.ID() == c.Game.GetMap().ID()
+	previousEntities := c.Game.Entities
+	c.snapshotTick = snapshot.Tick
+	if gameMap.ID() != c.Game.GetMap().ID() {
+		c.Game.SetMap(gameMap)
+	}
+	c.Game.Hill, c.Game.HillMovesAt = proto.GetBackendHill(snapshot.Hill)
+	c.Game.Entities = make(map[uuid.UUID]backend.Identifier)
+	if !interpolate {
+		c.interpolator.reset()
+	}
+	for _, entity := range entities {
+		player, ok := entity.(*backend.Player)
+		if ok && hasCurrentPlayer && player.ID() == c.CurrentPlayer {
+			if len(c.pendingMoves) > 0 {
+				player.Move(currentPlayer.Position())
+			}
+		} else if previousPlayer, exists := previousEntities[entity.ID()].(*backend.Player); ok && exists && interpolate {
+			c.interpolator.push(player.ID(), serverTime, player.Position())
+			player.Move(previousPlayer.Position())
+		}
+		c.Game.AddEntity(entity)
+	}
+	// Forget buffered positions of players that are gone.
+	for id := range previousEntities {
+		if _, ok := c.Game.Entities[id]; !ok {
+			c.interpolator.clear(id)
+		}
+	}
+	c.Game.Score = scores
+	c.Game.WaitForRound = false
+	if snapshot.RoundOver != nil {
+		return c.setRoundOver(snapshot.RoundOver)
+	}
 	return nil
 }
 
+// requestResync asks the server for a full snapshot, including the map. This
+// is used when the client notices that it has missed changes.
+func (c *GameClient) requestResync() {
+	if time.Since(c.lastResync) < resyncThrottle {
+		return
+	}
+	c.lastResync = time.Now()
+	req := proto.Request{
+		Action: &proto.Request_Resync{
+			Resync: &proto.Resync{},
+		},
+	}
+	c.send(&req)
+}
+
 // Exit stops the tview application and prints a message.
 // This is needed as stdout is mangled while tview is running.
 func (c *GameClient) Exit(message string) {
@@ -84,9 +434,9 @@ func (c *GameClient) Exit(message string) {
 // changes.
 func (c *GameClient) Start() {
 	// Handle local game engine changes.
+	subscription := c.Game.ChangeBus.Subscribe(changeQueueSize, backend.OverflowBlock)
 	go func() {
-		for {
-			change := <-c.Game.ChangeChannel
+		for change := range subscription.Changes {
 			switch change.(type) {
 			case backend.MoveChange:
 				change := change.(backend.MoveChange)
@@ -94,32 +444,81 @@ func (c *GameClient) Start() {
 			case backend.AddEntityChange:
 				change := change.(backend.AddEntityChange)
 				c.handleAddEntityChange(change)
+			case backend.WeaponChange:
+				change := change.(backend.WeaponChange)
+				c.handleWeaponChange(change)
 			}
 		}
 	}()
+	// Move remote players as their buffered positions become due.
+	go func() {
+		interpolationTicker := time.NewTicker(interpolationFrequency)
+		for range interpolationTicker.C {
+			c.Game.Mu.Lock()
+			c.applyInterpolation()
+			c.Game.Mu.Unlock()
+		}
+	}()
 	// Handle stream messages.
 	go func() {
 		for {
-			resp, err := c.Stream.Recv()
+			c.streamMu.Lock()
+			stream := c.Stream
+			c.streamMu.Unlock()
+			resp, err := stream.Recv()
 			if err != nil {
-				c.Exit(fmt.Sprintf("can not receive, error: %v", err))
-				return
+				// Try to continue where we left off if the connection broke.
+				if resumeErr := c.resume(); resumeErr != nil {
+					c.Exit(fmt.Sprintf("can not receive, error: %v", err))
+					return
+				}
+				continue
 			}
 
 			c.Game.Mu.Lock()
+			if !c.checkSequence(resp) {
+				c.Game.Mu.Unlock()
+				continue
+			}
 			switch resp.GetAction().(type) {
+			case *proto.Response_InputAck:
+				c.handleInputAckResponse(resp)
 			case *proto.Response_AddEntity:
 				c.handleAddEntityResponse(resp)
 			case *proto.Response_UpdateEntity:
 				c.handleUpdateEntityResponse(resp)
 			case *proto.Response_RemoveEntity:
 				c.handleRemoveEntityResponse(resp)
+			case *proto.Response_CollectPickup:
+				c.handleCollectPickupResponse(resp)
+			case *proto.Response_PlayerDamage:
+				c.handlePlayerDamageResponse(resp)
 			case *proto.Response_PlayerRespawn:
 				c.handlePlayerRespawnResponse(resp)
+			case *proto.Response_PlayerDeath:
+				c.handlePlayerDeathResponse(resp)
+			case *proto.Response_UpdateScore:
+				c.handleUpdateScoreResponse(resp)
+			case *proto.Response_PickupFlag:
+				c.handlePickupFlagResponse(resp)
+			case *proto.Response_DropFlag:
+				c.handleUpdateFlagResponse(resp.GetDropFlag().Flag)
+			case *proto.Response_ReturnFlag:
+				c.handleUpdateFlagResponse(resp.GetReturnFlag().Flag)
+			case *proto.Response_CaptureFlag:
+				c.handleUpdateFlagResponse(resp.GetCaptureFlag().Flag)
+			case *proto.Response_MoveHill:
+				c.handleMoveHillResponse(resp)
+			case *proto.Response_Snapshot:
+				c.handleSnapshotResponse(resp)
 			case *proto.Response_RoundOver:
 				c.handleRoundOverResponse(resp)
 			case *proto.Response_RoundStart:
 				c.handleRoundStartResponse(resp)
+			case *proto.Response_ChangeMap:
+				c.handleChangeMapResponse(resp)
+			case *proto.Response_LobbyState:
+				c.handleLobbyStateResponse(resp)
 			}
 			c.Game.Mu.Unlock()
 		}
@@ -134,9 +533,17 @@ func (c *GameClient) handleMoveChange(change backend.MoveChange) {
 			},
 		},
 	}
-	c.Stream.Send(&req)
-	// Store position history to help with stuttering.
-	c.positionHistory = append([]backend.Coordinate{change.Position}, c.positionHistory[:positionHistoryLimit]...)
+	c.send(&req)
+	// Remember the move so it can be replayed if the server corrects our
+	// position.
+	c.Game.Mu.Lock()
+	if req.Sequence > c.ackedRequest {
+		c.pendingMoves = append(c.pendingMoves, pendingMove{
+			sequence:  req.Sequence,
+			direction: change.Direction,
+		})
+	}
+	c.Game.Mu.Unlock()
 }
 
 func (c *GameClient) handleAddEntityChange(change backend.AddEntityChange) {
@@ -147,13 +554,64 @@ func (c *GameClient) handleAddEntityChange(change backend.AddEntityChange) {
 	switch change.Entity.(type) {
 	case *backend.Laser:
 		laser := change.Entity.(*backend.Laser)
+		// The server fires the rest of the volley itself.
+		if laser.VolleyIndex != 0 {
+			return
+		}
+		// Let the server check hits against what we were seeing.
+		c.Game.Mu.RLock()
+		viewTime, _ := ptypes.TimestampProto(c.interpolator.renderTime())
+		c.Game.Mu.RUnlock()
 		req := proto.Request{
+			ViewTime: viewTime,
 			Action: &proto.Request_Laser{
 				Laser: proto.GetProtoLaser(laser),
 			},
 		}
-		c.Stream.Send(&req)
+		c.send(&req)
+	}
+}
+
+func (c *GameClient) handleWeaponChange(change backend.WeaponChange) {
+	req := proto.Request{
+		Action: &proto.Request_SwitchWeapon{
+			SwitchWeapon: &proto.SwitchWeapon{
+				Weapon: proto.GetProtoWeapon(change.Weapon),
+			},
+		},
+	}
+	c.send(&req)
+}
+
+// handleInputAckResponse reconciles the local player with the server. The
+// player is moved to where the server says they were after the acknowledged
+// request, and moves the server has not processed yet are replayed on top.
+func (c *GameClient) handleInputAckResponse(resp *proto.Response) {
+	ack := resp.GetInputAck()
+	if ack.Sequence <= c.ackedRequest {
+		return
+	}
+	c.ackedRequest = ack.Sequence
+	pendingMoves := c.pendingMoves[:0]
+	for _, move := range c.pendingMoves {
+		if move.sequence > ack.Sequence {
+			pendingMoves = append(pendingMoves, move)
+		}
+	}
+	c.pendingMoves = pendingMoves
+
+	player, ok := c.Game.GetEntity(c.CurrentPlayer).(*backend.Player)
+	if !ok || !player.IsAlive() || ack.Position == nil {
+		return
+	}
+	position := proto.GetBackendCoordinate(ack.Position)
+	for _, move := range c.pendingMoves {
+		next, ok := c.Game.NextPosition(position, move.direction)
+		if ok {
+			position = next
+		}
 	}
+	player.Move(position)
 }
 
 func (c *GameClient) handleAddEntityResponse(resp *proto.Response) {
@@ -178,22 +636,33 @@ func (c *GameClient) handleUpdateEntityResponse(resp *proto.Response) {
 		c.Exit(fmt.Sprintf("can not get backend entity from %+v", entity))
 		return
 	}
-	// To prevent jittering, ignore updates for recent positions.
-	// Note: This feels OK, but isn't perfect. I think if I refactored the
-	// networking to use more targeted responses, i.e. "move confirmed" sent
-	// after a player moves, you could compare recent moves instead of
-	// positions and do something like rollback networking.
-	player, ok := entity.(*backend.Player)
-	if ok && player.ID() == c.CurrentPlayer {
-		for _, position := range c.positionHistory {
-			if player.Position() == position {
-				return
-			}
+	// Our own position is predicted locally, and corrected when the server
+	// acknowledges our moves. Other players are moved once their position is
+	// due to be displayed.
+	player, isPlayer := entity.(*backend.Player)
+	existingPlayer, exists := c.Game.GetEntity(entity.ID()).(*backend.Player)
+	if isPlayer && exists {
+		if player.ID() == c.CurrentPlayer {
+			player.Move(existingPlayer.Position())
+		} else if serverTime, err := ptypes.Timestamp(resp.ServerTime); err == nil {
+			c.interpolator.push(player.ID(), serverTime, player.Position())
+			player.Move(existingPlayer.Position())
 		}
 	}
 	c.Game.UpdateEntity(entity)
 }
 
+// applyInterpolation moves remote players to their buffered positions that
+// are due. The game lock must be held by the caller.
+func (c *GameClient) applyInterpolation() {
+	for id, position := range c.interpolator.due() {
+		player, ok := c.Game.GetEntity(id).(*backend.Player)
+		if ok {
+			player.Move(position)
+		}
+	}
+}
+
 func (c *GameClient) handleRemoveEntityResponse(resp *proto.Response) {
 	remove := resp.GetRemoveEntity()
 	id, err := uuid.Parse(remove.Id)
@@ -201,46 +670,174 @@ func (c *GameClient) handleRemoveEntityResponse(resp *proto.Response) {
 		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
 		return
 	}
+	c.interpolator.clear(id)
 	c.Game.RemoveEntity(id)
 }
 
-func (c *GameClient) handlePlayerRespawnResponse(resp *proto.Response) {
-	respawn := resp.GetPlayerRespawn()
-	killedByID, err := uuid.Parse(respawn.KilledById)
+func (c *GameClient) handleCollectPickupResponse(resp *proto.Response) {
+	collect := resp.GetCollectPickup()
+	pickupID, err := uuid.Parse(collect.PickupId)
 	if err != nil {
 		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
 		return
 	}
+	c.Game.RemoveEntity(pickupID)
+	update := proto.GetBackendPlayer(collect.Player)
+	if update == nil {
+		c.Exit(fmt.Sprintf("can not get backend player from %+v", collect.Player))
+		return
+	}
+	// Only update the effects of the player, as their position may be ahead
+	// of the server.
+	player, ok := c.Game.GetEntity(update.ID()).(*backend.Player)
+	if !ok {
+		c.requestResync()
+		return
+	}
+	player.Health = update.Health
+	player.Effects = update.Effects
+}
+
+func (c *GameClient) handlePlayerDamageResponse(resp *proto.Response) {
+	damage := resp.GetPlayerDamage()
+	playerID, err := uuid.Parse(damage.PlayerId)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	player, ok := c.Game.GetEntity(playerID).(*backend.Player)
+	if !ok {
+		c.requestResync()
+		return
+	}
+	player.Health = int(damage.Health)
+}
+
+func (c *GameClient) handlePlayerRespawnResponse(resp *proto.Response) {
+	respawn := resp.GetPlayerRespawn()
 	player := proto.GetBackendPlayer(respawn.Player)
 	if player == nil {
 		c.Exit(fmt.Sprintf("can not get backend player from %+v", respawn.Player))
 		return
 	}
-	c.Game.AddScore(killedByID)
+	c.interpolator.clear(player.ID())
 	c.Game.UpdateEntity(player)
 }
 
-func (c *GameClient) handleRoundOverResponse(resp *proto.Response) {
-	respawn := resp.GetRoundOver()
-	roundWinner, err := uuid.Parse(respawn.RoundWinnerId)
+func (c *GameClient) handlePlayerDeathResponse(resp *proto.Response) {
+	death := resp.GetPlayerDeath()
+	playerID, err := uuid.Parse(death.PlayerId)
 	if err != nil {
 		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
 		return
 	}
-	newRoundAt, err := ptypes.Timestamp(respawn.NewRoundAt)
+	player, ok := c.Game.GetEntity(playerID).(*backend.Player)
+	if !ok {
+		c.requestResync()
+		return
+	}
+	player.Health = 0
+	player.State = backend.PlayerDead
+}
+
+func (c *GameClient) handleUpdateScoreResponse(resp *proto.Response) {
+	update := resp.GetUpdateScore()
+	playerID, err := uuid.Parse(update.PlayerId)
 	if err != nil {
-		c.Exit(fmt.Sprintf("error when parsing timestamp: %v", err))
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
 		return
 	}
+	c.Game.Score[playerID] = int(update.Score)
+}
+
+func (c *GameClient) handlePickupFlagResponse(resp *proto.Response) {
+	pickup := resp.GetPickupFlag()
+	flagID, err := uuid.Parse(pickup.FlagId)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	playerID, err := uuid.Parse(pickup.PlayerId)
+	if err != nil {
+		c.Exit(fmt.Sprintf("error when parsing UUID: %v", err))
+		return
+	}
+	flag, ok := c.Game.GetEntity(flagID).(*backend.Flag)
+	if !ok {
+		c.requestResync()
+		return
+	}
+	flag.CarrierID = playerID
+}
+
+func (c *GameClient) handleUpdateFlagResponse(protoFlag *proto.Flag) {
+	flag := proto.GetBackendFlag(protoFlag)
+	if flag == nil {
+		c.Exit(fmt.Sprintf("can not get backend flag from %+v", protoFlag))
+		return
+	}
+	c.Game.UpdateEntity(flag)
+}
+
+func (c *GameClient) handleMoveHillResponse(resp *proto.Response) {
+	moveHill := resp.GetMoveHill()
+	c.Game.Hill, c.Game.HillMovesAt = proto.GetBackendHill(moveHill.Hill)
+}
+
+func (c *GameClient) handleSnapshotResponse(resp *proto.Response) {
+	serverTime, err := ptypes.Timestamp(resp.ServerTime)
+	if err != nil {
+		serverTime = time.Time{}
+	}
+	err = c.loadSnapshot(resp.GetSnapshot(), serverTime)
+	if err != nil {
+		c.Exit(fmt.Sprintf("can not load snapshot: %v", err))
+	}
+}
+
+func (c *GameClient) handleRoundOverResponse(resp *proto.Response) {
+	err := c.setRoundOver(resp.GetRoundOver())
+	if err != nil {
+		c.Exit(fmt.Sprintf("can not handle round over: %v", err))
+		return
+	}
+	c.Game.Score = make(map[uuid.UUID]int)
+}
+
+// setRoundOver makes the game wait for the next round.
+func (c *GameClient) setRoundOver(roundOver *proto.RoundOver) error {
+	roundWinner, err := uuid.Parse(roundOver.RoundWinnerId)
+	if err != nil {
+		return err
+	}
+	newRoundAt, err := ptypes.Timestamp(roundOver.NewRoundAt)
+	if err != nil {
+		return err
+	}
+	placements := make([][]uuid.UUID, 0, len(roundOver.Placements))
+	for _, placement := range roundOver.Placements {
+		group := make([]uuid.UUID, 0, len(placement.PlayerIds))
+		for _, playerID := range placement.PlayerIds {
+			id, err := uuid.Parse(playerID)
+			if err != nil {
+				return err
+			}
+			group = append(group, id)
+		}
+		placements = append(placements, group)
+	}
 	c.Game.RoundWinner = roundWinner
+	c.Game.RoundWinnerTeam = proto.GetBackendTeam(roundOver.RoundWinnerTeam)
+	c.Game.RoundPlacements = placements
 	c.Game.NewRoundAt = newRoundAt
 	c.Game.WaitForRound = true
-	c.Game.Score = make(map[uuid.UUID]int)
+	return nil
 }
 
 func (c *GameClient) handleRoundStartResponse(resp *proto.Response) {
 	roundStart := resp.GetRoundStart()
 	c.Game.WaitForRound = false
+	c.interpolator.reset()
 	for _, protoPlayer := range roundStart.Players {
 		player := proto.GetBackendPlayer(protoPlayer)
 		if player == nil {
@@ -250,3 +847,20 @@ func (c *GameClient) handleRoundStartResponse(resp *proto.Response) {
 		c.Game.AddEntity(player)
 	}
 }
+
+func (c *GameClient) handleChangeMapResponse(resp *proto.Response) {
+	changeMap := resp.GetChangeMap()
+	gameMap := proto.GetBackendMap(changeMap.Map)
+	if gameMap == nil {
+		c.Exit(fmt.Sprintf("can not get backend map from %+v", changeMap.Map))
+		return
+	}
+	c.Game.SetMap(gameMap)
+}
+
+func (c *GameClient) handleLobbyStateResponse(resp *proto.Response) {
+	c.lobby = resp.GetLobbyState()
+	if c.OnLobbyState != nil {
+		c.OnLobbyState(c.lobby)
+	}
+}
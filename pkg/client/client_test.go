@@ -0,0 +1,110 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/frontend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+func TestSnapshotPositionsAreInterpolated(t *testing.T) {
+	clock := backend.NewFakeClock(testStart)
+	game := backend.NewGame()
+	game.IsAuthoritative = false
+	game.Clock = clock
+	c := NewGameClient(game, frontend.NewView(game))
+	c.CurrentPlayer = uuid.New()
+	remote := &backend.Player{
+		IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+		Name:            "remote",
+		Icon:            'R',
+		CurrentPosition: backend.Coordinate{X: 0},
+		Health:          game.MaxHealth,
+	}
+	game.AddEntity(remote)
+
+	// A move is buffered, and then a snapshot arrives before it is due.
+	clock.Advance(10 * time.Millisecond)
+	c.interpolator.push(remote.ID(), testStart, backend.Coordinate{X: 1})
+	snapshotPlayer := *remote
+	snapshotPlayer.CurrentPosition = backend.Coordinate{X: 2}
+	clock.Advance(50 * time.Millisecond)
+	err := c.loadSnapshot(&proto.Snapshot{
+		Tick:     1,
+		Entities: []*proto.Entity{proto.GetProtoEntity(&snapshotPlayer)},
+		MapId:    game.GetMap().ID(),
+	}, testStart.Add(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("can not load snapshot: %v", err)
+	}
+
+	position := func() backend.Coordinate {
+		return game.GetEntity(remote.ID()).(*backend.Player).Position()
+	}
+	if position() != (backend.Coordinate{X: 0}) {
+		t.Fatalf("expected the snapshot to keep the displayed position, got %v", position())
+	}
+	// The buffered move is shown first, followed by the snapshot position.
+	clock.Advance(defaultInterpolationDelay - 50*time.Millisecond)
+	c.applyInterpolation()
+	if position() != (backend.Coordinate{X: 1}) {
+		t.Fatalf("expected the buffered move to be kept, got %v", position())
+	}
+	clock.Advance(50 * time.Millisecond)
+	c.applyInterpolation()
+	if position() != (backend.Coordinate{X: 2}) {
+		t.Fatalf("expected the snapshot position after the delay, got %v", position())
+	}
+}
+
+func TestInputAckReplaysPendingMoves(t *testing.T) {
+	game := backend.NewGame()
+	game.IsAuthoritative = false
+	c := NewGameClient(game, frontend.NewView(game))
+	player := &backend.Player{
+		IdentifierBase:  backend.IdentifierBase{UUID: uuid.New()},
+		Name:            "player",
+		Icon:            'P',
+		CurrentPosition: backend.Coordinate{X: 3, Y: 0},
+		Health:          game.MaxHealth,
+	}
+	game.AddEntity(player)
+	c.CurrentPlayer = player.ID()
+	c.pendingMoves = []pendingMove{
+		{sequence: 1, direction: backend.DirectionRight},
+		{sequence: 2, direction: backend.DirectionRight},
+		{sequence: 3, direction: backend.DirectionDown},
+	}
+
+	ack := func(sequence uint64, position backend.Coordinate) {
+		c.handleInputAckResponse(&proto.Response{
+			Action: &proto.Response_InputAck{
+				InputAck: &proto.InputAck{
+					Sequence: sequence,
+					Position: proto.GetProtoCoordinate(position),
+				},
+			},
+		})
+	}
+	// The server processed the first move, but ended up somewhere else.
+	ack(1, backend.Coordinate{X: 0, Y: 0})
+	if len(c.pendingMoves) != 2 || c.pendingMoves[0].sequence != 2 {
+		t.Fatalf("expected the acknowledged move to be dropped, got %+v", c.pendingMoves)
+	}
+	if player.Position() != (backend.Coordinate{X: 1, Y: 1}) {
+		t.Errorf("expected pending moves to be replayed from the server position, got %v", player.Position())
+	}
+
+	// Acknowledgements that arrive late are ignored.
+	ack(1, backend.Coordinate{X: 0, Y: 0})
+	if len(c.pendingMoves) != 2 || player.Position() != (backend.Coordinate{X: 1, Y: 1}) {
+		t.Errorf("expected a stale acknowledgement to be ignored")
+	}
+	ack(3, backend.Coordinate{X: 1, Y: 1})
+	if len(c.pendingMoves) != 0 {
+		t.Errorf("expected every move to be acknowledged, got %+v", c.pendingMoves)
+	}
+}
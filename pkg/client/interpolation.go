@@ -0,0 +1,103 @@
+package client
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+// interpolationSample is the position of a remote entity at a point in
+// server time.
+type interpolationSample struct {
+	serverTime time.Time
+	position   backend.Coordinate
+}
+
+// interpolator buffers position updates for remote entities and releases them
+// a fixed delay behind the server. This way entities move at the pace the
+// server moved them, instead of whenever the updates happened to arrive.
+// It is not safe for concurrent use, the client only uses it while holding
+// the game lock.
+type interpolator struct {
+	clock backend.Clock
+	delay time.Duration
+	// lag is the shortest time seen between the server sending an update and
+	// the client receiving it. It also absorbs any difference between the
+	// server and client clocks.
+	lag     time.Duration
+	hasLag  bool
+	samples map[uuid.UUID][]interpolationSample
+}
+
+// newInterpolator constructs a new interpolator that uses the given clock.
+func newInterpolator(clock backend.Clock, delay time.Duration) *interpolator {
+	return &interpolator{
+		clock:   clock,
+		delay:   delay,
+		samples: make(map[uuid.UUID][]interpolationSample),
+	}
+}
+
+// push buffers the position of an entity, as sent by the server at
+// serverTime. Samples older than the last buffered sample are ignored.
+func (i *interpolator) push(id uuid.UUID, serverTime time.Time, position backend.Coordinate) {
+	lag := i.clock.Now().Sub(serverTime)
+	if !i.hasLag || lag < i.lag {
+		i.lag = lag
+		i.hasLag = true
+	}
+	samples := i.samples[id]
+	if len(samples) > 0 && serverTime.Before(samples[len(samples)-1].serverTime) {
+		return
+	}
+	i.samples[id] = append(samples, interpolationSample{
+		serverTime: serverTime,
+		position:   position,
+	})
+}
+
+// due returns the latest position that should be displayed for every entity
+// with buffered samples that are old enough, and removes those samples.
+func (i *interpolator) due() map[uuid.UUID]backend.Coordinate {
+	positions := make(map[uuid.UUID]backend.Coordinate)
+	renderTime := i.renderTime()
+	for id, samples := range i.samples {
+		count := 0
+		for _, sample := range samples {
+			if sample.serverTime.After(renderTime) {
+				break
+			}
+			positions[id] = sample.position
+			count++
+		}
+		if count == len(samples) {
+			delete(i.samples, id)
+		} else {
+			i.samples[id] = samples[count:]
+		}
+	}
+	return positions
+}
+
+// renderTime determines the server time that remote entities are currently
+// displayed at. It is zero until an update has been received from the server.
+func (i *interpolator) renderTime() time.Time {
+	if !i.hasLag {
+		return time.Time{}
+	}
+	return i.clock.Now().Add(-i.lag - i.delay)
+}
+
+// clear forgets the buffered samples for an entity. This is used when the
+// entity is moved by something other than a position update, i.e. a respawn.
+func (i *interpolator) clear(id uuid.UUID) {
+	delete(i.samples, id)
+}
+
+// reset forgets all buffered samples and the measured lag.
+func (i *interpolator) reset() {
+	i.samples = make(map[uuid.UUID][]interpolationSample)
+	i.lag = 0
+	i.hasLag = false
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+var testStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestInterpolatorReleasesPositionsAfterDelay(t *testing.T) {
+	clock := backend.NewFakeClock(testStart)
+	interpolator := newInterpolator(clock, 100*time.Millisecond)
+	id := uuid.New()
+
+	// Updates arrive 20ms after the server sent them.
+	clock.Advance(20 * time.Millisecond)
+	interpolator.push(id, testStart, backend.Coordinate{X: 1})
+	if positions := interpolator.due(); len(positions) != 0 {
+		t.Fatalf("expected no positions before the delay, got %v", positions)
+	}
+	clock.Advance(100 * time.Millisecond)
+	positions := interpolator.due()
+	if positions[id] != (backend.Coordinate{X: 1}) {
+		t.Fatalf("expected the first position after the delay, got %v", positions)
+	}
+	if positions := interpolator.due(); len(positions) != 0 {
+		t.Fatalf("expected positions to be released once, got %v", positions)
+	}
+}
+
+func TestInterpolatorKeepsServerSpacing(t *testing.T) {
+	clock := backend.NewFakeClock(testStart)
+	interpolator := newInterpolator(clock, 100*time.Millisecond)
+	id := uuid.New()
+
+	// Three moves sent 50ms apart arrive at the same time, as if they were
+	// held up by the network.
+	clock.Advance(150 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		serverTime := testStart.Add(time.Duration(i) * 50 * time.Millisecond)
+		interpolator.push(id, serverTime, backend.Coordinate{X: i})
+	}
+	// The lag is measured from the latest move, so the first move is due
+	// right away and the others follow 50ms apart.
+	for i := 0; i < 3; i++ {
+		positions := interpolator.due()
+		if positions[id] != (backend.Coordinate{X: i}) {
+			t.Errorf("step %d: expected %v, got %v", i, backend.Coordinate{X: i}, positions)
+		}
+		clock.Advance(50 * time.Millisecond)
+	}
+}
+
+func TestInterpolatorIgnoresOutOfOrderSamples(t *testing.T) {
+	clock := backend.NewFakeClock(testStart)
+	interpolator := newInterpolator(clock, 0)
+	id := uuid.New()
+
+	interpolator.push(id, testStart.Add(50*time.Millisecond), backend.Coordinate{X: 2})
+	interpolator.push(id, testStart, backend.Coordinate{X: 1})
+	clock.Advance(time.Second)
+	positions := interpolator.due()
+	if positions[id] != (backend.Coordinate{X: 2}) {
+		t.Fatalf("expected the latest position, got %v", positions)
+	}
+}
+
+func TestInterpolatorClear(t *testing.T) {
+	clock := backend.NewFakeClock(testStart)
+	interpolator := newInterpolator(clock, 100*time.Millisecond)
+	first := uuid.New()
+	second := uuid.New()
+
+	interpolator.push(first, testStart, backend.Coordinate{X: 1})
+	interpolator.push(second, testStart, backend.Coordinate{X: 2})
+	interpolator.clear(first)
+	clock.Advance(time.Second)
+	positions := interpolator.due()
+	if _, ok := positions[first]; ok {
+		t.Errorf("expected cleared samples to be forgotten, got %v", positions)
+	}
+	if positions[second] != (backend.Coordinate{X: 2}) {
+		t.Errorf("expected other samples to be kept, got %v", positions)
+	}
+}
+
+func TestInterpolatorResetMeasuresLagAgain(t *testing.T) {
+	clock := backend.NewFakeClock(testStart)
+	interpolator := newInterpolator(clock, 100*time.Millisecond)
+	id := uuid.New()
+
+	interpolator.push(id, testStart, backend.Coordinate{X: 1})
+	interpolator.reset()
+	// After a reset, the lag of the next update is used even if it is
+	// longer than the lag seen before.
+	clock.Advance(500 * time.Millisecond)
+	interpolator.push(id, testStart, backend.Coordinate{X: 2})
+	if positions := interpolator.due(); len(positions) != 0 {
+		t.Fatalf("expected no positions before the delay, got %v", positions)
+	}
+	clock.Advance(100 * time.Millisecond)
+	positions := interpolator.due()
+	if positions[id] != (backend.Coordinate{X: 2}) {
+		t.Errorf("expected only the position pushed after the reset, got %v", positions)
+	}
+}
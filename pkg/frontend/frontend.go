@@ -14,14 +14,69 @@ import (
 )
 
 const (
-	backgroundColor = tcell.Color234
-	textColor       = tcell.ColorWhite
-	playerColor     = tcell.ColorWhite
-	wallColor       = tcell.Color24
-	laserColor      = tcell.ColorRed
-	drawFrequency   = 17 * time.Millisecond
+	backgroundColor   = tcell.Color234
+	textColor         = tcell.ColorWhite
+	playerColor       = tcell.ColorWhite
+	wallColor         = tcell.Color24
+	laserColor        = tcell.ColorRed
+	healthColor       = tcell.ColorRed
+	pickupColor       = tcell.ColorGreen
+	hillColor         = tcell.Color58
+	redTeamColor      = tcell.ColorIndianRed
+	blueTeamColor     = tcell.ColorDodgerBlue
+	drawFrequency     = 17 * time.Millisecond
+	playerHelpText    = "← → ↑ ↓ move - wasd shoot - 1234 weapon - p score - l leaderboard - esc close - ctrl+q quit"
+	spectatorHelpText = "← → ↑ ↓ move camera - tab follow player - p score - l leaderboard - esc close - ctrl+q quit"
+	flagIcon          = '⚑'
+	flagBaseIcon      = '□'
 )
 
+// laserIcons maps weapons to the icon used to draw their lasers.
+var laserIcons = map[backend.WeaponType]rune{
+	backend.WeaponLaser:   'x',
+	backend.WeaponShotgun: '•',
+	backend.WeaponHeavy:   'O',
+	backend.WeaponSniper:  '+',
+}
+
+// pickupIcons maps pickup kinds to the icon used to draw them.
+var pickupIcons = map[backend.PickupKind]rune{
+	backend.PickupHealth:     '+',
+	backend.PickupSpeedBoost: '»',
+	backend.PickupRapidFire:  '*',
+	backend.PickupShield:     '◊',
+}
+
+// teamColors maps teams to the color used to draw their players.
+var teamColors = map[backend.Team]tcell.Color{
+	backend.TeamNone: playerColor,
+	backend.TeamRed:  redTeamColor,
+	backend.TeamBlue: blueTeamColor,
+}
+
+// flagBaseTeams maps flag base map types to their team.
+var flagBaseTeams = map[backend.MapType]backend.Team{
+	backend.MapTypeFlagRed:  backend.TeamRed,
+	backend.MapTypeFlagBlue: backend.TeamBlue,
+}
+
+// weaponKeys maps keys to the weapon they switch to.
+var weaponKeys = map[rune]backend.WeaponType{
+	'1': backend.WeaponLaser,
+	'2': backend.WeaponShotgun,
+	'3': backend.WeaponHeavy,
+	'4': backend.WeaponSniper,
+}
+
+// LeaderboardEntry is a player's place on the server's ladder.
+type LeaderboardEntry struct {
+	Rank   int
+	Name   string
+	Rating int
+	Rounds int
+	Wins   int
+}
+
 // View renders the game and handles user interaction.
 type View struct {
 	Game          *backend.Game
@@ -31,6 +86,16 @@ type View struct {
 	drawCallbacks []func()
 	viewPort      tview.Primitive
 	Done          chan error
+	// Spectator views have no current player, and instead follow other
+	// players or move the camera freely.
+	Spectator  bool
+	following  uuid.UUID
+	freeRoam   bool
+	freeCamera backend.Coordinate
+	// Leaderboard fetches the players with the highest ratings. The
+	// leaderboard can only be shown if it is set.
+	Leaderboard     func() ([]LeaderboardEntry, error)
+	leaderboardText *tview.TextView
 }
 
 func centeredModal(p tview.Primitive) tview.Primitive {
@@ -60,12 +125,33 @@ func setupRoundWaitModal(view *View) {
 		defer view.Game.Mu.RUnlock()
 		if view.Game.WaitForRound {
 			view.pages.ShowPage("roundwait")
-			seconds := int(view.Game.NewRoundAt.Sub(time.Now()).Seconds())
+			seconds := int(view.Game.NewRoundAt.Sub(view.Game.Clock.Now()).Seconds())
 			if seconds < 0 {
 				seconds = 0
 			}
-			player := view.Game.GetEntity(view.Game.RoundWinner).(*backend.Player)
-			text := fmt.Sprintf("\nWinner: %s\n\n", player.Name)
+			text := "\n"
+			player, ok := view.Game.GetEntity(view.Game.RoundWinner).(*backend.Player)
+			if view.Game.RoundWinnerTeam != backend.TeamNone {
+				text += fmt.Sprintf("Winner: %s team\n\n", view.Game.RoundWinnerTeam)
+			} else if ok {
+				text += fmt.Sprintf("Winner: %s\n\n", player.Name)
+			}
+			// Tied players share a place, and the places after them are skipped.
+			place := 1
+			for _, group := range view.Game.RoundPlacements {
+				groupPlace := place
+				for _, id := range group {
+					player, ok := view.Game.GetEntity(id).(*backend.Player)
+					if !ok {
+						continue
+					}
+					text += fmt.Sprintf("%d. %s\n", groupPlace, player.Name)
+					place++
+				}
+			}
+			if place > 1 {
+				text += "\n"
+			}
 			text += fmt.Sprintf("New round in %d seconds...", seconds)
 			textView.SetText(text)
 		} else {
@@ -91,6 +177,8 @@ func setupScoreModal(view *View) {
 			Score int
 		}
 		playerScore := make([]PlayerScore, 0)
+		teamScore := make(map[backend.Team]int)
+		hasTeams := false
 		for _, entity := range view.Game.Entities {
 			player, ok := entity.(*backend.Player)
 			if !ok {
@@ -100,6 +188,10 @@ func setupScoreModal(view *View) {
 			if !ok {
 				score = 0
 			}
+			if player.Team != backend.TeamNone {
+				hasTeams = true
+				teamScore[player.Team] += score
+			}
 			playerScore = append(playerScore, PlayerScore{
 				Name:  player.Name,
 				Score: score,
@@ -115,6 +207,26 @@ func setupScoreModal(view *View) {
 			}
 			return false
 		})
+		if view.Game.Hill != nil {
+			king := view.Game.HillKing()
+			if king != nil {
+				text += fmt.Sprintf("%s is king of the hill\n", king.Name)
+			}
+			if !view.Game.HillMovesAt.IsZero() {
+				seconds := int(view.Game.HillMovesAt.Sub(view.Game.Clock.Now()).Seconds())
+				if seconds < 0 {
+					seconds = 0
+				}
+				text += fmt.Sprintf("Hill moves in %d seconds\n", seconds)
+			}
+			text += "\n"
+		}
+		if hasTeams {
+			for _, team := range backend.Teams {
+				text += fmt.Sprintf("%s team - %d\n", team, teamScore[team])
+			}
+			text += "\n"
+		}
 		for _, playerScore := range playerScore {
 			text += fmt.Sprintf("%s - %d\n", playerScore.Name, playerScore.Score)
 		}
@@ -124,6 +236,39 @@ func setupScoreModal(view *View) {
 	view.pages.AddPage("score", modal, true, false)
 }
 
+func setupLeaderboardModal(view *View) {
+	textView := tview.NewTextView()
+	textView.SetBorder(true).SetTitle("Leaderboard").SetBackgroundColor(backgroundColor)
+	modal := centeredModal(textView)
+	view.leaderboardText = textView
+	view.pages.AddPage("leaderboard", modal, true, false)
+}
+
+// showLeaderboard shows the leaderboard and fetches it in the background, as
+// it is not part of the game state.
+func (view *View) showLeaderboard() {
+	if view.Leaderboard == nil {
+		return
+	}
+	view.leaderboardText.SetText("Loading...")
+	view.pages.ShowPage("leaderboard")
+	go func() {
+		entries, err := view.Leaderboard()
+		text := ""
+		if err != nil {
+			text = fmt.Sprintf("Can not load the leaderboard: %v", err)
+		} else if len(entries) == 0 {
+			text = "Nobody has finished a round yet"
+		}
+		for _, entry := range entries {
+			text += fmt.Sprintf("%d. %s - %d (%d wins in %d rounds)\n", entry.Rank, entry.Name, entry.Rating, entry.Wins, entry.Rounds)
+		}
+		view.App.QueueUpdateDraw(func() {
+			view.leaderboardText.SetText(text)
+		})
+	}()
+}
+
 func withinDrawBounds(x, y, width, height int) bool {
 	return x < width && x > 0 && y < height && y > 0
 }
@@ -140,13 +285,16 @@ func setupViewPort(view *View) {
 		defer view.Game.Mu.RUnlock()
 		style := tcell.StyleDefault.Background(backgroundColor)
 		// Move camera
-		currentEntity := view.Game.GetEntity(view.CurrentPlayer)
-		if currentEntity == nil {
+		cameraTarget, ok := view.cameraTarget()
+		if !ok {
 			return 0, 0, 0, 0
 		}
-		currentPlayerPosition := currentEntity.(*backend.Player).Position()
-		cameraDiffX := float64(cameraX - currentPlayerPosition.X)
-		cameraDiffY := float64(cameraY - currentPlayerPosition.Y)
+		if view.Spectator && view.freeRoam {
+			cameraX = cameraTarget.X
+			cameraY = cameraTarget.Y
+		}
+		cameraDiffX := float64(cameraX - cameraTarget.X)
+		cameraDiffY := float64(cameraY - cameraTarget.Y)
 		cameraDiffXMax := float64(width / 6)
 		cameraDiffYMax := float64(height / 6)
 		if math.Abs(cameraDiffX) > cameraDiffXMax {
@@ -178,6 +326,40 @@ func setupViewPort(view *View) {
 		// if withinDrawBounds(centerX, centerY, width, height) {
 		// 	screen.SetContent(centerX, centerY, 'C', nil, style.Foreground(tcell.ColorWhite))
 		// }
+		// Draw the hill
+		hill := view.Game.Hill
+		if hill != nil {
+			for hillY := hill.Position.Y; hillY < hill.Position.Y+hill.Height; hillY++ {
+				for hillX := hill.Position.X; hillX < hill.Position.X+hill.Width; hillX++ {
+					x := centerX + hillX
+					y := centerY + hillY
+					if !withinDrawBounds(x, y, width, height) {
+						continue
+					}
+					screen.SetContent(x, y, ' ', nil, style.Background(hillColor))
+				}
+			}
+		}
+		// Draw flag bases
+		mapByType := view.Game.GetMapByType()
+		for mapType, team := range flagBaseTeams {
+			for _, base := range mapByType[mapType] {
+				x := centerX + base.X
+				y := centerY + base.Y
+				if !withinDrawBounds(x, y, width, height) {
+					continue
+				}
+				screen.SetContent(x, y, flagBaseIcon, nil, style.Foreground(teamColors[team]))
+			}
+		}
+		// Find flag carriers so they can be highlighted.
+		carriedFlags := make(map[uuid.UUID]*backend.Flag)
+		for _, entity := range view.Game.Entities {
+			flag, ok := entity.(*backend.Flag)
+			if ok && flag.IsCarried() {
+				carriedFlags[flag.CarrierID] = flag
+			}
+		}
 		// Draw entities
 		for _, entity := range view.Game.Entities {
 			positioner, ok := entity.(backend.Positioner)
@@ -192,21 +374,45 @@ func setupViewPort(view *View) {
 			}
 			var icon rune
 			var color tcell.Color
+			entityStyle := style
+			if hill != nil && hill.Contains(position) {
+				entityStyle = style.Background(hillColor)
+			}
 			switch entity.(type) {
 			case *backend.Player:
-				icon = entity.(*backend.Player).Icon
-				color = playerColor
+				player := entity.(*backend.Player)
+				// Dead players are spectating.
+				if !player.IsAlive() {
+					continue
+				}
+				icon = player.Icon
+				color = teamColors[player.Team]
+				flag, ok := carriedFlags[player.ID()]
+				if ok {
+					entityStyle = style.Background(teamColors[flag.Team]).Bold(true)
+				}
 			case *backend.Laser:
-				icon = 'x'
+				icon = laserIcons[entity.(*backend.Laser).Weapon]
 				color = laserColor
+			case *backend.Pickup:
+				icon = pickupIcons[entity.(*backend.Pickup).Kind]
+				color = pickupColor
+			case *backend.Flag:
+				flag := entity.(*backend.Flag)
+				// Carried flags are shown by highlighting their carrier.
+				if flag.IsCarried() {
+					continue
+				}
+				icon = flagIcon
+				color = teamColors[flag.Team]
 			default:
 				continue
 			}
 			// See if player is far from center of viewport.
-			screen.SetContent(drawX, drawY, icon, nil, style.Foreground(color))
+			screen.SetContent(drawX, drawY, icon, nil, entityStyle.Foreground(color))
 		}
 		// Draw map
-		for _, wall := range view.Game.GetMapByType()[backend.MapTypeWall] {
+		for _, wall := range mapByType[backend.MapTypeWall] {
 			x := centerX + wall.X
 			y := centerY + wall.Y
 			if !withinDrawBounds(x, y, width, height) {
@@ -218,6 +424,10 @@ func setupViewPort(view *View) {
 	})
 	// Handle player movement input.
 	box.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
+		if view.Spectator {
+			view.handleSpectatorInput(e)
+			return e
+		}
 		// Movement
 		direction := backend.DirectionStop
 		switch e.Key() {
@@ -234,7 +444,7 @@ func setupViewPort(view *View) {
 			view.Game.ActionChannel <- backend.MoveAction{
 				ID:        view.CurrentPlayer,
 				Direction: direction,
-				Created:   time.Now(),
+				Created:   view.Game.Clock.Now(),
 			}
 		}
 		// Lasers
@@ -254,24 +464,185 @@ func setupViewPort(view *View) {
 				OwnerID:   view.CurrentPlayer,
 				ID:        uuid.New(),
 				Direction: laserDirection,
-				Created:   time.Now(),
+				Created:   view.Game.Clock.Now(),
+			}
+		}
+		// Weapons
+		weapon, ok := weaponKeys[e.Rune()]
+		if ok {
+			view.Game.ActionChannel <- backend.SwitchWeaponAction{
+				ID:      view.CurrentPlayer,
+				Weapon:  weapon,
+				Created: view.Game.Clock.Now(),
 			}
 		}
 		return e
 	})
 	helpText := tview.NewTextView().
 		SetTextAlign(tview.AlignCenter).
-		SetText("← → ↑ ↓ move - wasd shoot - p score - esc close - ctrl+q quit").
+		SetText(playerHelpText).
 		SetTextColor(textColor)
 	helpText.SetBackgroundColor(backgroundColor)
+	view.drawCallbacks = append(view.drawCallbacks, func() {
+		if view.Spectator {
+			helpText.SetText(spectatorHelpText)
+		}
+	})
+	statusText := setupStatusText(view)
 	flex := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(box, 0, 1, true).
+		AddItem(statusText, 1, 1, false).
 		AddItem(helpText, 1, 1, false)
 	view.pages.AddPage("viewport", flex, true, true)
 	view.viewPort = box
 }
 
+// cameraTarget determines the position the camera should move towards.
+// Spectators follow a player, unless they are moving the camera freely.
+func (view *View) cameraTarget() (backend.Coordinate, bool) {
+	if !view.Spectator {
+		player, ok := view.Game.GetEntity(view.CurrentPlayer).(*backend.Player)
+		if !ok {
+			return backend.Coordinate{}, false
+		}
+		return player.Position(), true
+	}
+	if view.freeRoam {
+		return view.freeCamera, true
+	}
+	player, ok := view.Game.GetEntity(view.following).(*backend.Player)
+	if !ok || !player.IsAlive() {
+		view.followNextPlayer()
+		player, ok = view.Game.GetEntity(view.following).(*backend.Player)
+	}
+	if !ok {
+		return view.freeCamera, true
+	}
+	view.freeCamera = player.Position()
+	return player.Position(), true
+}
+
+// followNextPlayer makes a spectator follow the next living player, ordered
+// by name.
+func (view *View) followNextPlayer() {
+	players := make([]*backend.Player, 0)
+	for _, entity := range view.Game.Entities {
+		player, ok := entity.(*backend.Player)
+		if ok && player.IsAlive() {
+			players = append(players, player)
+		}
+	}
+	if len(players) == 0 {
+		view.following = uuid.Nil
+		return
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return strings.ToLower(players[i].Name) < strings.ToLower(players[j].Name)
+	})
+	next := players[0]
+	for i, player := range players {
+		if player.ID() == view.following {
+			next = players[(i+1)%len(players)]
+			break
+		}
+	}
+	view.following = next.ID()
+}
+
+// handleSpectatorInput moves the camera freely with the arrow keys, and
+// follows the next player with the tab key.
+func (view *View) handleSpectatorInput(e *tcell.EventKey) {
+	view.Game.Mu.RLock()
+	defer view.Game.Mu.RUnlock()
+	difference := backend.Coordinate{}
+	switch e.Key() {
+	case tcell.KeyUp:
+		difference.Y = -1
+	case tcell.KeyDown:
+		difference.Y = 1
+	case tcell.KeyLeft:
+		difference.X = -1
+	case tcell.KeyRight:
+		difference.X = 1
+	case tcell.KeyTab:
+		view.freeRoam = false
+		view.followNextPlayer()
+		return
+	default:
+		return
+	}
+	view.freeRoam = true
+	// Game coordinates are centered on the middle of the map.
+	mapWidth, mapHeight := view.Game.GetMapDimensions()
+	minX, minY := -mapWidth/2, -mapHeight/2
+	position := view.freeCamera.Add(difference)
+	if position.X >= minX && position.Y >= minY && position.X < minX+mapWidth && position.Y < minY+mapHeight {
+		view.freeCamera = position
+	}
+}
+
+// setupStatusText creates a text view that shows the current player's health,
+// weapon, and active effects.
+func setupStatusText(view *View) *tview.TextView {
+	textView := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetTextColor(healthColor)
+	textView.SetBackgroundColor(backgroundColor)
+	callback := func() {
+		view.Game.Mu.RLock()
+		defer view.Game.Mu.RUnlock()
+		if view.Spectator {
+			following, ok := view.Game.GetEntity(view.following).(*backend.Player)
+			if view.freeRoam || !ok {
+				textView.SetText("Spectating - Free camera")
+			} else {
+				textView.SetText(fmt.Sprintf("Spectating - Following %s", following.Name))
+			}
+			return
+		}
+		player, ok := view.Game.GetEntity(view.CurrentPlayer).(*backend.Player)
+		if !ok {
+			textView.SetText("")
+			return
+		}
+		if !player.IsAlive() {
+			textView.SetText("Eliminated - spectating until the round is over")
+			return
+		}
+		health := player.Health
+		if health < 0 {
+			health = 0
+		}
+		text := fmt.Sprintf("Health %s - %s", strings.Repeat("♥", health), backend.Weapons[player.Weapon].Name)
+		now := view.Game.Clock.Now()
+		for _, kind := range []backend.PickupKind{
+			backend.PickupSpeedBoost,
+			backend.PickupRapidFire,
+			backend.PickupShield,
+		} {
+			if player.HasEffect(kind, now) {
+				seconds := int(player.Effects[kind].Sub(now).Seconds())
+				text += fmt.Sprintf(" - %s %ds", kind, seconds)
+			}
+		}
+		if view.Game.Hill != nil && view.Game.Hill.Contains(player.Position()) {
+			if view.Game.HillKing() == player {
+				text += " - King of the hill"
+			} else {
+				text += " - Hill contested"
+			}
+		}
+		flag := view.Game.CarriedFlag(player.ID())
+		if flag != nil {
+			text += fmt.Sprintf(" - Carrying %s flag", flag.Team)
+		}
+		textView.SetText(text)
+	}
+	view.drawCallbacks = append(view.drawCallbacks, callback)
+	return textView
+}
+
 // NewView construsts a new View struct.
 func NewView(game *backend.Game) *View {
 	app := tview.NewApplication()
@@ -286,13 +657,18 @@ func NewView(game *backend.Game) *View {
 	setupViewPort(view)
 	setupScoreModal(view)
 	setupRoundWaitModal(view)
+	setupLeaderboardModal(view)
 	app.SetInputCapture(func(e *tcell.EventKey) *tcell.EventKey {
 		if e.Rune() == 'p' {
 			pages.ShowPage("score")
 		}
+		if e.Rune() == 'l' {
+			view.showLeaderboard()
+		}
 		switch e.Key() {
 		case tcell.KeyEsc:
 			pages.HidePage("score")
+			pages.HidePage("leaderboard")
 			app.SetFocus(view.viewPort)
 		case tcell.KeyCtrlQ:
 			fallthrough
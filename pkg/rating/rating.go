@@ -0,0 +1,193 @@
+package rating
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultRating is the rating of players who have not finished a round.
+	DefaultRating = 1000
+	// kFactor is the most a player's rating can change after one round.
+	kFactor = 32
+)
+
+// Player is the rating and record of one player.
+type Player struct {
+	Name   string  `json:"name"`
+	Rating float64 `json:"rating"`
+	Rounds int     `json:"rounds"`
+	Wins   int     `json:"wins"`
+}
+
+// Ratings tracks the skill of players by name, using the Elo rating system.
+// Ratings are saved to a file after every round if a path is set, so that
+// they are kept when the server restarts. It is safe for concurrent use.
+type Ratings struct {
+	players map[string]*Player
+	path    string
+	mu      sync.RWMutex
+}
+
+// NewRatings constructs an empty set of ratings that are only kept in memory.
+func NewRatings() *Ratings {
+	return &Ratings{
+		players: make(map[string]*Player),
+	}
+}
+
+// LoadRatings loads ratings from a file, which is created when the first
+// round ends if it does not exist.
+func LoadRatings(path string) (*Ratings, error) {
+	ratings := NewRatings()
+	ratings.path = path
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ratings, nil
+	} else if err != nil {
+		return nil, err
+	}
+	players := make([]*Player, 0)
+	err = json.Unmarshal(data, &players)
+	if err != nil {
+		return nil, err
+	}
+	for _, player := range players {
+		ratings.players[player.Name] = player
+	}
+	return ratings, nil
+}
+
+// Get returns the rating of a player.
+func (r *Ratings) Get(name string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return int(math.Round(r.get(name)))
+}
+
+// get returns the exact rating of a player. The caller must hold the lock.
+func (r *Ratings) get(name string) float64 {
+	player, ok := r.players[name]
+	if !ok {
+		return DefaultRating
+	}
+	return player.Rating
+}
+
+// Top returns up to limit players with the highest ratings, best first.
+func (r *Ratings) Top(limit int) []Player {
+	r.mu.RLock()
+	players := make([]Player, 0, len(r.players))
+	for _, player := range r.players {
+		players = append(players, *player)
+	}
+	r.mu.RUnlock()
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Rating != players[j].Rating {
+			return players[i].Rating > players[j].Rating
+		}
+		return strings.ToLower(players[i].Name) < strings.ToLower(players[j].Name)
+	})
+	if len(players) > limit {
+		players = players[:limit]
+	}
+	return players
+}
+
+// RecordRound updates ratings from the placements of a finished round, given
+// as groups of tied players, best first. Every player is treated as having won
+// against the players placed below them, lost against the players placed
+// above them, and drawn with the players they tied with. Players in the first
+// group are credited with a win unless everyone tied. Players who appear
+// more than once, like two clients using the same name, are only rated at
+// their best placement. Rounds with less than two players are not rated.
+func (r *Ratings) RecordRound(placements [][]string) error {
+	names := make([]string, 0)
+	places := make([]int, 0)
+	seen := make(map[string]bool)
+	for place, group := range placements {
+		for _, name := range group {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+			places = append(places, place)
+		}
+	}
+	if len(names) < 2 {
+		return nil
+	}
+	everyoneTied := places[0] == places[len(places)-1]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	before := make([]float64, len(names))
+	for i, name := range names {
+		before[i] = r.get(name)
+	}
+	// Split the K-factor between opponents, so that players do not gain more
+	// rating for winning in a larger game.
+	k := kFactor / float64(len(names)-1)
+	for i, name := range names {
+		change := 0.0
+		for j := range names {
+			if i == j {
+				continue
+			}
+			actual := 0.5
+			if places[i] < places[j] {
+				actual = 1
+			} else if places[i] > places[j] {
+				actual = 0
+			}
+			change += k * (actual - expectedScore(before[i], before[j]))
+		}
+		player, ok := r.players[name]
+		if !ok {
+			player = &Player{Name: name}
+			r.players[name] = player
+		}
+		player.Rating = before[i] + change
+		player.Rounds++
+		if places[i] == places[0] && !everyoneTied {
+			player.Wins++
+		}
+	}
+	return r.save()
+}
+
+// save writes all ratings to the file, if any. The file is replaced at once
+// so that it is not left half written if the server stops. The caller must
+// hold the lock.
+func (r *Ratings) save() error {
+	if r.path == "" {
+		return nil
+	}
+	players := make([]*Player, 0, len(r.players))
+	for _, player := range r.players {
+		players = append(players, player)
+	}
+	sort.Slice(players, func(i, j int) bool {
+		return players[i].Name < players[j].Name
+	})
+	data, err := json.MarshalIndent(players, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(r.path+".tmp", data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(r.path+".tmp", r.path)
+}
+
+// expectedScore is the chance of a player with the given rating beating an
+// opponent.
+func expectedScore(rating float64, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+}
@@ -0,0 +1,98 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+// checkRating fails the test if a player's exact rating is not as expected.
+func checkRating(t *testing.T, ratings *Ratings, name string, expected float64) {
+	t.Helper()
+	if rating := ratings.get(name); math.Abs(rating-expected) > 0.001 {
+		t.Errorf("expected %s to have a rating of %.3f, got %.3f", name, expected, rating)
+	}
+}
+
+func TestRecordRoundPairwise(t *testing.T) {
+	ratings := NewRatings()
+	if err := ratings.RecordRound([][]string{{"winner"}, {"loser"}}); err != nil {
+		t.Fatal(err)
+	}
+	checkRating(t, ratings, "winner", DefaultRating+kFactor/2)
+	checkRating(t, ratings, "loser", DefaultRating-kFactor/2)
+
+	// The favourite gains less for winning again, and the K-factor is split
+	// between opponents.
+	if err := ratings.RecordRound([][]string{{"winner"}, {"loser"}, {"new"}}); err != nil {
+		t.Fatal(err)
+	}
+	k := kFactor / 2.0
+	winner := DefaultRating + kFactor/2.0
+	loser := DefaultRating - kFactor/2.0
+	checkRating(t, ratings, "winner", winner+
+		k*(1-expectedScore(winner, loser))+
+		k*(1-expectedScore(winner, DefaultRating)))
+	checkRating(t, ratings, "loser", loser+
+		k*(0-expectedScore(loser, winner))+
+		k*(1-expectedScore(loser, DefaultRating)))
+	checkRating(t, ratings, "new", DefaultRating+
+		k*(0-expectedScore(DefaultRating, winner))+
+		k*(0-expectedScore(DefaultRating, loser)))
+
+	top := ratings.Top(1)
+	if len(top) != 1 || top[0].Name != "winner" || top[0].Rounds != 2 || top[0].Wins != 2 {
+		t.Errorf("expected the winner at the top with 2 wins in 2 rounds, got %+v", top)
+	}
+}
+
+func TestRecordRoundTiedGroups(t *testing.T) {
+	ratings := NewRatings()
+	if err := ratings.RecordRound([][]string{{"a", "b"}, {"c"}}); err != nil {
+		t.Fatal(err)
+	}
+	// Tied players draw with each other and beat the player below them.
+	checkRating(t, ratings, "a", DefaultRating+8)
+	checkRating(t, ratings, "b", DefaultRating+8)
+	checkRating(t, ratings, "c", DefaultRating-16)
+	for _, player := range ratings.Top(3) {
+		wins := 0
+		if player.Name != "c" {
+			wins = 1
+		}
+		if player.Wins != wins {
+			t.Errorf("expected %s to have %d wins, got %d", player.Name, wins, player.Wins)
+		}
+	}
+
+	// Nobody wins when everyone tied.
+	ratings = NewRatings()
+	if err := ratings.RecordRound([][]string{{"a", "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range ratings.Top(2) {
+		if player.Rating != DefaultRating || player.Wins != 0 || player.Rounds != 1 {
+			t.Errorf("expected a draw to leave %s unchanged, got %+v", player.Name, player)
+		}
+	}
+}
+
+func TestRecordRoundDuplicateNames(t *testing.T) {
+	ratings := NewRatings()
+	if err := ratings.RecordRound([][]string{{"twin"}, {"other"}, {"twin"}}); err != nil {
+		t.Fatal(err)
+	}
+	checkRating(t, ratings, "twin", DefaultRating+kFactor/2)
+	checkRating(t, ratings, "other", DefaultRating-kFactor/2)
+	if top := ratings.Top(1); top[0].Rounds != 1 {
+		t.Errorf("expected the round to count once, got %d rounds", top[0].Rounds)
+	}
+
+	// A player alone with themselves is not rated.
+	ratings = NewRatings()
+	if err := ratings.RecordRound([][]string{{"twin"}, {"twin"}}); err != nil {
+		t.Fatal(err)
+	}
+	if top := ratings.Top(1); len(top) != 0 {
+		t.Errorf("expected no ratings, got %+v", top)
+	}
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+// lobbyMember contains the choices a client has made in the lobby.
+type lobbyMember struct {
+	name     string
+	icon     rune
+	team     backend.Team
+	ready    bool
+	joinedAt time.Time
+}
+
+// OpenLobby makes new players wait in a lobby until the match starts, either
+// because the host started it or everyone is ready. Players that are already
+// in the game are not affected.
+func (s *GameServer) OpenLobby() {
+	s.mu.Lock()
+	s.inLobby = true
+	s.mu.Unlock()
+}
+
+// isInLobby checks if the match has not started yet.
+func (s *GameServer) isInLobby() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inLobby
+}
+
+// newPlayer creates a player at a random spawn point. The team is only used
+// if the game mode has teams, otherwise the mode chooses a team when the
+// player is added. The game lock must be held by the caller.
+func (s *GameServer) newPlayer(playerID uuid.UUID, name string, icon rune, team backend.Team) *backend.Player {
+	if !s.game.Mode.Teams() {
+		team = backend.TeamNone
+	}
+	spawnPoints := s.game.SpawnPoints(team)
+	rand.Seed(time.Now().Unix())
+	i := rand.Int() % len(spawnPoints)
+	return &backend.Player{
+		Name:            name,
+		Icon:            icon,
+		IdentifierBase:  backend.IdentifierBase{UUID: playerID},
+		CurrentPosition: spawnPoints[i],
+		Health:          s.game.MaxHealth,
+		Team:            team,
+	}
+}
+
+// parseIcon validates an icon chosen in the lobby.
+func parseIcon(icon string) (rune, error) {
+	if utf8.RuneCountInString(icon) != 1 {
+		return 0, errors.New("icons must be a single character")
+	}
+	r, _ := utf8.DecodeRuneInString(strings.ToUpper(icon))
+	if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+		return 0, errors.New("icons must be a letter or number")
+	}
+	return r, nil
+}
+
+// getLobbyMembers returns the clients waiting in the lobby in the order they
+// joined. The first client is the host. The caller must hold the server lock.
+func (s *GameServer) getLobbyMembers() []*client {
+	members := make([]*client, 0)
+	for _, currentClient := range s.clients {
+		if !currentClient.spectator {
+			members = append(members, currentClient)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].lobby.joinedAt.Before(members[j].lobby.joinedAt)
+	})
+	return members
+}
+
+// getProtoLobbyState describes the lobby for clients. The caller must hold
+// the server lock.
+func (s *GameServer) getProtoLobbyState() *proto.LobbyState {
+	s.game.Mu.RLock()
+	teams := s.game.Mode.Teams()
+	mode := s.game.Mode.Name()
+	s.game.Mu.RUnlock()
+	lobbyState := &proto.LobbyState{
+		Teams:   teams,
+		Mode:    mode,
+		Started: !s.inLobby,
+	}
+	for i, member := range s.getLobbyMembers() {
+		lobbyState.Members = append(lobbyState.Members, &proto.LobbyMember{
+			Id:    member.playerID.String(),
+			Name:  member.lobby.name,
+			Icon:  string(member.lobby.icon),
+			Team:  proto.GetProtoTeam(member.lobby.team),
+			Ready: member.lobby.ready,
+			Host:  i == 0,
+		})
+	}
+	return lobbyState
+}
+
+// broadcastLobbyState tells all clients who is in the lobby.
+func (s *GameServer) broadcastLobbyState() {
+	s.mu.RLock()
+	lobbyState := s.getProtoLobbyState()
+	s.mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_LobbyState{
+			LobbyState: lobbyState,
+		},
+	}
+	s.broadcast(&resp)
+}
+
+// handleUpdateLobbyRequest changes a client's choices in the lobby, and
+// starts the match once everyone is ready.
+func (s *GameServer) handleUpdateLobbyRequest(req *proto.Request, currentClient *client) {
+	updateLobby := req.GetUpdateLobby()
+	s.mu.Lock()
+	if !s.inLobby {
+		s.mu.Unlock()
+		return
+	}
+	currentClient.lobby.team = proto.GetBackendTeam(updateLobby.Team)
+	currentClient.lobby.ready = updateLobby.Ready
+	if updateLobby.Icon != "" {
+		icon, err := parseIcon(updateLobby.Icon)
+		if err != nil {
+			log.Printf("%s - %v", currentClient.id, err)
+		} else {
+			currentClient.lobby.icon = icon
+		}
+	}
+	allReady := true
+	for _, member := range s.getLobbyMembers() {
+		if !member.lobby.ready {
+			allReady = false
+		}
+	}
+	s.mu.Unlock()
+
+	if allReady {
+		s.startMatch()
+		return
+	}
+	s.broadcastLobbyState()
+}
+
+// handleStartMatchRequest starts the match if the client is the host.
+func (s *GameServer) handleStartMatchRequest(req *proto.Request, currentClient *client) {
+	s.mu.RLock()
+	members := s.getLobbyMembers()
+	isHost := len(members) > 0 && members[0] == currentClient
+	s.mu.RUnlock()
+	if !isHost {
+		return
+	}
+	s.startMatch()
+}
+
+// startMatch closes the lobby, adds a player for everyone who was waiting,
+// and starts a new round.
+func (s *GameServer) startMatch() {
+	s.mu.Lock()
+	if !s.inLobby {
+		s.mu.Unlock()
+		return
+	}
+	s.inLobby = false
+	members := s.getLobbyMembers()
+	s.mu.Unlock()
+
+	log.Printf("starting match with %d players", len(members))
+	s.game.Mu.Lock()
+	for _, member := range members {
+		if s.game.GetEntity(member.playerID) != nil {
+			continue
+		}
+		player := s.newPlayer(member.playerID, member.lobby.name, member.lobby.icon, member.lobby.team)
+		s.game.AddPlayer(player)
+	}
+	// Sends every player to clients, so AddEntity responses are not needed.
+	s.game.StartRound()
+	s.game.Mu.Unlock()
+
+	s.broadcastLobbyState()
+}
@@ -0,0 +1,281 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+const (
+	// DefaultMatchSize is the number of players in a match, including bots,
+	// if clients do not choose one.
+	DefaultMatchSize = 4
+	// matchmakingInterval is how often the queue is checked for matches, as
+	// the allowed rating spread grows while players wait.
+	matchmakingInterval = time.Second
+	// initialRatingSpread is how far apart the ratings of players in a match
+	// can be when they join the queue.
+	initialRatingSpread = 100
+	// ratingSpreadPerSecond is how much the allowed rating spread grows for
+	// every second a player waits.
+	ratingSpreadPerSecond = 20
+	// botFillTimeout is how long players wait for others to join the queue
+	// before the rest of the match is filled with bots.
+	botFillTimeout = 30 * time.Second
+)
+
+// ticket is a player waiting in the matchmaking queue.
+type ticket struct {
+	name     string
+	rating   int
+	mode     string
+	size     int
+	queuedAt time.Time
+	// match receives the result once the player has been matched.
+	match chan matchResult
+}
+
+// matchResult is sent to players when a match is created for them.
+type matchResult struct {
+	resp *proto.MatchmakeResponse
+	err  error
+}
+
+// allowedSpread determines how far from a player's rating other players in
+// their match can be. The longer the player waits, the less picky they are.
+func (currentTicket *ticket) allowedSpread(now time.Time) int {
+	waited := now.Sub(currentTicket.queuedAt)
+	return initialRatingSpread + int(waited.Seconds()*ratingSpreadPerSecond)
+}
+
+// Matchmake puts the player in the queue for a match of the requested mode
+// and size. Players are matched with others of a similar rating, and the
+// match is filled with bots if nobody else is around. The request blocks
+// until the match's room has been created.
+func (manager *RoomManager) Matchmake(ctx context.Context, req *proto.MatchmakeRequest) (*proto.MatchmakeResponse, error) {
+	re := regexp.MustCompile("^[a-zA-Z0-9]+$")
+	if !re.MatchString(req.Name) {
+		return nil, errors.New("invalid name provided")
+	}
+	mode := req.Mode
+	if mode == "" {
+		mode = "deathmatch"
+	}
+	if _, ok := backend.GameModes[mode]; !ok {
+		return nil, fmt.Errorf("unknown game mode %q", mode)
+	}
+	size := int(req.Size)
+	if size == 0 {
+		size = DefaultMatchSize
+	}
+	if size < 2 || size > maxClients {
+		return nil, fmt.Errorf("matches can have 2 to %d players", maxClients)
+	}
+
+	newTicket := &ticket{
+		name:     req.Name,
+		rating:   manager.Ratings.Get(req.Name),
+		mode:     mode,
+		size:     size,
+		queuedAt: time.Now(),
+		match:    make(chan matchResult, 1),
+	}
+	manager.queueMu.Lock()
+	for _, queuedTicket := range manager.queue {
+		if queuedTicket.name == req.Name {
+			manager.queueMu.Unlock()
+			return nil, errors.New("a player with that name is already in the queue")
+		}
+	}
+	manager.queue = append(manager.queue, newTicket)
+	manager.queueMu.Unlock()
+	log.Printf("%s joined the %s queue with a rating of %d", req.Name, mode, newTicket.rating)
+	manager.findMatches()
+
+	select {
+	case result := <-newTicket.match:
+		return result.resp, result.err
+	case <-ctx.Done():
+		manager.dequeue(newTicket)
+		return nil, ctx.Err()
+	}
+}
+
+// dequeue removes a player from the queue.
+func (manager *RoomManager) dequeue(oldTicket *ticket) {
+	manager.queueMu.Lock()
+	defer manager.queueMu.Unlock()
+	for i, queuedTicket := range manager.queue {
+		if queuedTicket == oldTicket {
+			manager.queue = append(manager.queue[:i], manager.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// watchQueue periodically looks for matches, as players become less picky
+// the longer they wait.
+func (manager *RoomManager) watchQueue() {
+	ticker := time.NewTicker(matchmakingInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				manager.findMatches()
+			case <-manager.done:
+				return
+			}
+		}
+	}()
+}
+
+// findMatches creates matches for players in the queue. Rooms are created
+// after the queue is unlocked, so that players can join and leave the queue
+// in the meantime.
+func (manager *RoomManager) findMatches() {
+	for _, players := range manager.takeMatches(time.Now()) {
+		manager.createMatch(players)
+	}
+}
+
+// takeMatches removes players who can be matched from the queue, grouped by
+// match. The player who has waited longest is matched first, with the players
+// closest to their rating. If not enough players are close enough, the player
+// keeps waiting until botFillTimeout has passed and the match is filled with
+// bots.
+func (manager *RoomManager) takeMatches(now time.Time) [][]*ticket {
+	manager.queueMu.Lock()
+	defer manager.queueMu.Unlock()
+	matches := make([][]*ticket, 0)
+	sort.SliceStable(manager.queue, func(i, j int) bool {
+		return manager.queue[i].queuedAt.Before(manager.queue[j].queuedAt)
+	})
+	matched := make(map[*ticket]bool)
+	for _, anchor := range manager.queue {
+		if matched[anchor] {
+			continue
+		}
+		candidates := make([]*ticket, 0)
+		for _, candidate := range manager.queue {
+			if candidate == anchor || matched[candidate] || candidate.mode != anchor.mode || candidate.size != anchor.size {
+				continue
+			}
+			if abs(candidate.rating-anchor.rating) <= anchor.allowedSpread(now) {
+				candidates = append(candidates, candidate)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return abs(candidates[i].rating-anchor.rating) < abs(candidates[j].rating-anchor.rating)
+		})
+		if len(candidates) > anchor.size-1 {
+			candidates = candidates[:anchor.size-1]
+		}
+		if len(candidates) < anchor.size-1 && now.Sub(anchor.queuedAt) < botFillTimeout {
+			continue
+		}
+		players := append([]*ticket{anchor}, candidates...)
+		for _, player := range players {
+			matched[player] = true
+		}
+		matches = append(matches, players)
+	}
+	queue := make([]*ticket, 0)
+	for _, queuedTicket := range manager.queue {
+		if !matched[queuedTicket] {
+			queue = append(queue, queuedTicket)
+		}
+	}
+	manager.queue = queue
+	return matches
+}
+
+// createMatch creates a room for matched players and tells them how to join
+// it. The room has a random password so that only the matched players can
+// join, and empty slots are filled with bots.
+func (manager *RoomManager) createMatch(players []*ticket) {
+	mode := players[0].mode
+	size := players[0].size
+	manager.queueMu.Lock()
+	manager.matchCount++
+	name := fmt.Sprintf("match%d", manager.matchCount)
+	manager.queueMu.Unlock()
+	password := uuid.New().String()
+	bots := size - len(players)
+	err := manager.AddRoom(RoomOptions{
+		Name:     name,
+		Password: password,
+		Mode:     mode,
+		Maps:     manager.maps,
+		Shuffle:  true,
+		Bots:     bots,
+	})
+	if err != nil {
+		log.Printf("can not create match: %v", err)
+		for _, player := range players {
+			player.match <- matchResult{err: fmt.Errorf("can not create match: %v", err)}
+		}
+		return
+	}
+	log.Printf("created %s match %q for %d players and %d bots", mode, name, len(players), bots)
+
+	teams := balanceTeams(players, mode, bots)
+	for _, player := range players {
+		player.match <- matchResult{
+			resp: &proto.MatchmakeResponse{
+				Room:     name,
+				Password: password,
+				Team:     proto.GetProtoTeam(teams[player]),
+				Rating:   int32(player.rating),
+				Players:  int32(len(players)),
+				Bots:     int32(bots),
+			},
+		}
+	}
+}
+
+// balanceTeams splits players into teams with similar total ratings, if the
+// game mode has teams. When the match has bots the game mode chooses teams
+// instead, so that teams have the same number of players.
+func balanceTeams(players []*ticket, mode string, bots int) map[*ticket]backend.Team {
+	teams := make(map[*ticket]backend.Team)
+	if !backend.GameModes[mode]().Teams() || bots > 0 {
+		return teams
+	}
+	sorted := append([]*ticket{}, players...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].rating > sorted[j].rating
+	})
+	totals := make(map[backend.Team]int)
+	counts := make(map[backend.Team]int)
+	for _, player := range sorted {
+		// Fill the team with the lowest total rating, unless it already has
+		// its half of the players.
+		team := backend.TeamRed
+		if counts[backend.TeamRed] >= (len(players)+1)/2 ||
+			(counts[backend.TeamBlue] < (len(players)+1)/2 && totals[backend.TeamBlue] < totals[backend.TeamRed]) {
+			team = backend.TeamBlue
+		}
+		teams[player] = team
+		totals[team] += player.rating
+		counts[team]++
+	}
+	return teams
+}
+
+// abs returns the absolute value of an integer.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
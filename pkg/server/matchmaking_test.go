@@ -0,0 +1,73 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestBalanceTeams(t *testing.T) {
+	players := []*ticket{
+		{name: "a", rating: 1100},
+		{name: "b", rating: 1400},
+		{name: "c", rating: 1200},
+		{name: "d", rating: 1300},
+	}
+	teams := balanceTeams(players, "teamdeathmatch", 0)
+	totals := make(map[backend.Team]int)
+	counts := make(map[backend.Team]int)
+	for _, player := range players {
+		totals[teams[player]] += player.rating
+		counts[teams[player]]++
+	}
+	if counts[backend.TeamRed] != 2 || counts[backend.TeamBlue] != 2 {
+		t.Errorf("expected two players on each team, got %v", counts)
+	}
+	if totals[backend.TeamRed] != totals[backend.TeamBlue] {
+		t.Errorf("expected teams with the same total rating, got %v", totals)
+	}
+
+	if teams := balanceTeams(players, "deathmatch", 0); len(teams) != 0 {
+		t.Errorf("expected no teams in a free for all, got %v", teams)
+	}
+	if teams := balanceTeams(players, "teamdeathmatch", 2); len(teams) != 0 {
+		t.Errorf("expected the game mode to choose teams in matches with bots, got %v", teams)
+	}
+}
+
+func TestRatingSpreadWidensWhileWaiting(t *testing.T) {
+	queuedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager := &RoomManager{
+		queue: []*ticket{
+			{name: "a", rating: 1000, mode: "deathmatch", size: 2, queuedAt: queuedAt},
+			{name: "b", rating: 1250, mode: "deathmatch", size: 2, queuedAt: queuedAt.Add(time.Second)},
+		},
+	}
+
+	if matches := manager.takeMatches(queuedAt.Add(2 * time.Second)); len(matches) != 0 {
+		t.Fatalf("expected players to be too far apart at first, got %d matches", len(matches))
+	}
+	if len(manager.queue) != 2 {
+		t.Fatalf("expected both players to stay in the queue")
+	}
+	matches := manager.takeMatches(queuedAt.Add(8 * time.Second))
+	if len(matches) != 1 || len(matches[0]) != 2 || matches[0][0].name != "a" {
+		t.Fatalf("expected the players to be matched once the spread has grown, got %v", matches)
+	}
+	if len(manager.queue) != 0 {
+		t.Errorf("expected matched players to leave the queue")
+	}
+}
+
+func TestRoomManagerStop(t *testing.T) {
+	manager := NewRoomManager(backend.NewGame, nil)
+	if err := manager.AddRoom(RoomOptions{Name: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	manager.Stop()
+	manager.Stop()
+	if len(manager.rooms) != 0 {
+		t.Errorf("expected rooms to be removed")
+	}
+}
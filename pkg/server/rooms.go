@@ -0,0 +1,387 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+	"github.com/mortenson/grpc-game-example/pkg/bot"
+	"github.com/mortenson/grpc-game-example/pkg/rating"
+	"github.com/mortenson/grpc-game-example/proto"
+)
+
+const (
+	// DefaultRoom is the room clients join if they do not choose one.
+	DefaultRoom = "main"
+	maxRooms    = 16
+	maxRoomBots = 8
+	// defaultLeaderboardSize and maxLeaderboardSize limit the number of
+	// players sent in leaderboards.
+	defaultLeaderboardSize = 10
+	maxLeaderboardSize     = 100
+	// emptyRoomTimeout is how long a new room waits for its first client
+	// before it is removed.
+	emptyRoomTimeout = time.Minute
+)
+
+// RoomOptions configure a new room.
+type RoomOptions struct {
+	Name     string
+	Password string
+	// Mode is one of backend.GameModes, deathmatch is used if empty.
+	Mode string
+	// Maps are rotated through each round, the default map is used if
+	// empty.
+	Maps    []*backend.Map
+	Shuffle bool
+	Bots    int
+	// Persistent rooms are kept when their last client leaves.
+	Persistent bool
+	// Lobby makes players wait in a lobby until the match starts. Persistent
+	// rooms return to the lobby when everyone leaves.
+	Lobby bool
+}
+
+// room is a game, its bots, and the server that streams it to clients.
+type room struct {
+	name        string
+	mode        string
+	hasPassword bool
+	persistent  bool
+	lobby       bool
+	game        *backend.Game
+	bots        *bot.Bots
+	server      *GameServer
+}
+
+// RoomManager runs any number of games, called rooms, on one gRPC server.
+// Clients choose a room when connecting, or are matched into one, and rooms
+// that are not persistent are removed once their last client leaves.
+type RoomManager struct {
+	proto.UnimplementedGameServer
+	// Ratings are updated when rounds end in any room, and used to match
+	// players of similar skill. Ratings are only kept in memory unless
+	// replaced with ratings loaded from a file before rooms are added.
+	Ratings *rating.Ratings
+	rooms   map[string]*room
+	mu      sync.RWMutex
+	maps    []*backend.Map
+	newGame func() *backend.Game
+	queue   []*ticket
+	queueMu sync.Mutex
+	// matchCount is used to name match rooms, protected by queueMu.
+	matchCount int
+	done       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewRoomManager constructs a new room manager. newGame is called to create
+// the game for each room, so that server wide settings like the tick rate
+// apply to every room. Clients can choose from maps when creating rooms.
+func NewRoomManager(newGame func() *backend.Game, maps []*backend.Map) *RoomManager {
+	manager := &RoomManager{
+		Ratings: rating.NewRatings(),
+		rooms:   make(map[string]*room),
+		maps:    maps,
+		newGame: newGame,
+		queue:   make([]*ticket, 0),
+		done:    make(chan struct{}),
+	}
+	manager.watchQueue()
+	return manager
+}
+
+// Stop stops matchmaking and every room's game.
+func (manager *RoomManager) Stop() {
+	manager.stopOnce.Do(func() {
+		close(manager.done)
+		manager.mu.Lock()
+		rooms := manager.rooms
+		manager.rooms = make(map[string]*room)
+		manager.mu.Unlock()
+		for _, oldRoom := range rooms {
+			oldRoom.bots.Stop()
+			oldRoom.game.Stop()
+			oldRoom.server.Stop()
+		}
+	})
+}
+
+// AddRoom creates a new room and starts its game.
+func (manager *RoomManager) AddRoom(options RoomOptions) error {
+	re := regexp.MustCompile("^[a-zA-Z0-9]+$")
+	if !re.MatchString(options.Name) {
+		return errors.New("invalid room name provided")
+	}
+	if options.Mode == "" {
+		options.Mode = "deathmatch"
+	}
+	newMode, ok := backend.GameModes[options.Mode]
+	if !ok {
+		return fmt.Errorf("unknown game mode %q", options.Mode)
+	}
+	if options.Bots < 0 || options.Bots > maxRoomBots {
+		return fmt.Errorf("rooms can have up to %d bots", maxRoomBots)
+	}
+
+	manager.mu.Lock()
+	defer manager.mu.Unlock()
+	if _, ok := manager.rooms[options.Name]; ok {
+		return errors.New("a room with that name already exists")
+	}
+	if len(manager.rooms) >= maxRooms {
+		return errors.New("the server has too many rooms")
+	}
+
+	game := manager.newGame()
+	game.Mode = newMode()
+	maps := options.Maps
+	if len(maps) == 0 {
+		maps = []*backend.Map{game.GetMap()}
+	}
+	if err := backend.CheckMaps(game.Mode, maps); err != nil {
+		return err
+	}
+	if len(options.Maps) > 0 {
+		game.MapRotation = backend.NewMapRotation(options.Maps, options.Shuffle)
+		game.SetMap(game.MapRotation.Next())
+	}
+	bots := bot.NewBots(game)
+	for i := 0; i < options.Bots; i++ {
+		bots.AddBot(fmt.Sprintf("Bob %d", i))
+	}
+	game.Start()
+	bots.Start()
+
+	newRoom := &room{
+		name:        options.Name,
+		mode:        options.Mode,
+		hasPassword: options.Password != "",
+		persistent:  options.Persistent,
+		lobby:       options.Lobby,
+		game:        game,
+		bots:        bots,
+		server:      NewGameServer(game, options.Password),
+	}
+	newRoom.server.onEmpty = func() {
+		manager.removeRoomIfEmpty(newRoom)
+	}
+	newRoom.server.onRoundOver = func(placements [][]string) {
+		err := manager.Ratings.RecordRound(placements)
+		if err != nil {
+			log.Printf("can not save ratings: %v", err)
+		}
+	}
+	if options.Lobby {
+		newRoom.server.OpenLobby()
+	}
+	manager.rooms[options.Name] = newRoom
+	log.Printf("created room %q", options.Name)
+
+	// Remove rooms that nobody joins.
+	if !options.Persistent {
+		time.AfterFunc(emptyRoomTimeout, func() {
+			manager.removeRoomIfEmpty(newRoom)
+		})
+	}
+	return nil
+}
+
+// removeRoomIfEmpty stops a room's game and removes it if it has no clients
+// and is not persistent. Persistent rooms go back to their lobby instead.
+func (manager *RoomManager) removeRoomIfEmpty(oldRoom *room) {
+	if oldRoom.persistent {
+		if oldRoom.lobby {
+			oldRoom.server.OpenLobby()
+		}
+		return
+	}
+	manager.mu.Lock()
+	if manager.rooms[oldRoom.name] != oldRoom || oldRoom.server.countClients(false)+oldRoom.server.countClients(true) > 0 {
+		manager.mu.Unlock()
+		return
+	}
+	delete(manager.rooms, oldRoom.name)
+	manager.mu.Unlock()
+
+	log.Printf("removing empty room %q", oldRoom.name)
+	oldRoom.bots.Stop()
+	oldRoom.game.Stop()
+	oldRoom.server.Stop()
+}
+
+// getRoomByToken finds the room of the client with the given token.
+func (manager *RoomManager) getRoomByToken(token uuid.UUID) (*room, error) {
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+	for _, currentRoom := range manager.rooms {
+		if currentRoom.server.hasClient(token) {
+			return currentRoom, nil
+		}
+	}
+	return nil, errors.New("token not recognized")
+}
+
+// CreateRoom creates a room that clients can join. The room is removed when
+// the last client leaves.
+func (manager *RoomManager) CreateRoom(ctx context.Context, req *proto.CreateRoomRequest) (*proto.Room, error) {
+	maps := manager.maps
+	if req.Map != "" {
+		maps = nil
+		for _, gameMap := range manager.maps {
+			if gameMap.Name == req.Map {
+				maps = []*backend.Map{gameMap}
+				break
+			}
+		}
+		if maps == nil {
+			return nil, fmt.Errorf("unknown map %q", req.Map)
+		}
+	}
+	err := manager.AddRoom(RoomOptions{
+		Name:     req.Name,
+		Password: req.Password,
+		Mode:     req.Mode,
+		Maps:     maps,
+		Bots:     int(req.Bots),
+		Lobby:    req.Lobby,
+	})
+	if err != nil {
+		return nil, err
+	}
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+	newRoom, ok := manager.rooms[req.Name]
+	if !ok {
+		return nil, errors.New("room was removed")
+	}
+	return newRoom.getProtoRoom(), nil
+}
+
+// ListRooms lists all rooms, sorted by name.
+func (manager *RoomManager) ListRooms(ctx context.Context, req *proto.ListRoomsRequest) (*proto.ListRoomsResponse, error) {
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+	rooms := make([]*proto.Room, 0)
+	for _, currentRoom := range manager.rooms {
+		rooms = append(rooms, currentRoom.getProtoRoom())
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		return rooms[i].Name < rooms[j].Name
+	})
+	return &proto.ListRoomsResponse{
+		Rooms: rooms,
+	}, nil
+}
+
+// Leaderboard lists the players with the highest ratings.
+func (manager *RoomManager) Leaderboard(ctx context.Context, req *proto.LeaderboardRequest) (*proto.LeaderboardResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultLeaderboardSize
+	}
+	if limit > maxLeaderboardSize {
+		limit = maxLeaderboardSize
+	}
+	entries := make([]*proto.LeaderboardEntry, 0)
+	for i, player := range manager.Ratings.Top(limit) {
+		entries = append(entries, &proto.LeaderboardEntry{
+			Rank:   int32(i + 1),
+			Name:   player.Name,
+			Rating: int32(math.Round(player.Rating)),
+			Rounds: int32(player.Rounds),
+			Wins:   int32(player.Wins),
+		})
+	}
+	return &proto.LeaderboardResponse{
+		Entries: entries,
+	}, nil
+}
+
+// Connect adds a client to the requested room.
+func (manager *RoomManager) Connect(ctx context.Context, req *proto.ConnectRequest) (*proto.ConnectResponse, error) {
+	name := req.Room
+	if name == "" {
+		name = DefaultRoom
+	}
+	// Hold the lock while connecting, so that the room can not be removed
+	// before the client is added.
+	manager.mu.RLock()
+	defer manager.mu.RUnlock()
+	currentRoom, ok := manager.rooms[name]
+	if !ok {
+		return nil, fmt.Errorf("room %q does not exist", name)
+	}
+	return currentRoom.server.Connect(ctx, req)
+}
+
+// Stream passes the stream to the client's room.
+func (manager *RoomManager) Stream(srv proto.Game_StreamServer) error {
+	token, err := getTokenFromContext(srv.Context())
+	if err != nil {
+		return err
+	}
+	currentRoom, err := manager.getRoomByToken(token)
+	if err != nil {
+		return err
+	}
+	return currentRoom.server.Stream(srv)
+}
+
+// Resume passes the resume request to the client's room.
+func (manager *RoomManager) Resume(ctx context.Context, req *proto.ResumeRequest) (*proto.ConnectResponse, error) {
+	token, err := uuid.Parse(req.Token)
+	if err != nil {
+		return nil, errors.New("cannot parse token")
+	}
+	currentRoom, err := manager.getRoomByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return currentRoom.server.Resume(ctx, req)
+}
+
+// Leave removes the client from their room.
+func (manager *RoomManager) Leave(ctx context.Context, req *proto.LeaveRequest) (*proto.LeaveResponse, error) {
+	token, err := uuid.Parse(req.Token)
+	if err != nil {
+		return nil, errors.New("cannot parse token")
+	}
+	currentRoom, err := manager.getRoomByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return currentRoom.server.Leave(ctx, req)
+}
+
+// getProtoRoom describes the room for clients choosing a room to join.
+func (currentRoom *room) getProtoRoom() *proto.Room {
+	currentRoom.game.Mu.RLock()
+	players := 0
+	for _, entity := range currentRoom.game.Entities {
+		if _, ok := entity.(*backend.Player); ok {
+			players++
+		}
+	}
+	mapName := currentRoom.game.GetMap().Name
+	currentRoom.game.Mu.RUnlock()
+	return &proto.Room{
+		Name:        currentRoom.name,
+		Mode:        currentRoom.mode,
+		Map:         mapName,
+		Players:     int32(players),
+		MaxPlayers:  maxClients,
+		Spectators:  int32(currentRoom.server.countClients(true)),
+		HasPassword: currentRoom.hasPassword,
+		InLobby:     currentRoom.server.isInLobby(),
+	}
+}
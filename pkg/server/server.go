@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"log"
-	"math/rand"
 	"regexp"
 	"strings"
 	"sync"
@@ -20,17 +19,59 @@ import (
 )
 
 const (
-	clientTimeout = 15
-	maxClients    = 8
+	clientTimeout   = 15
+	maxClients      = 8
+	maxSpectators   = 8
+	changeQueueSize = 256
+	// sendQueueSize is how many responses can wait to be sent to a client.
+	// Clients that fall further behind have to resume.
+	sendQueueSize = 256
+	// resumeGracePeriod is how long a disconnected client's player is kept
+	// in the game, waiting for the client to resume.
+	resumeGracePeriod = 30 * time.Second
+	// snapshotInterval is how often clients are sent the full game state.
+	snapshotInterval = 5 * time.Second
+	resyncThrottle   = time.Second
+)
+
+var (
+	errReceiveFailed   = errors.New("failed to receive request")
+	errBroadcastFailed = errors.New("failed to broadcast message")
+	errClientLeft      = errors.New("client left the game")
+	errServerStopped   = errors.New("the game has ended")
 )
 
 // client contains information about connected clients.
 type client struct {
 	streamServer proto.Game_StreamServer
-	lastMessage  time.Time
-	done         chan error
-	playerID     uuid.UUID
-	id           uuid.UUID
+	// sendQueue holds responses until they are sent on the stream, so that
+	// a slow client does not hold up the game or other clients.
+	sendQueue   chan *proto.Response
+	lastMessage time.Time
+	done        chan error
+	playerID    uuid.UUID
+	id          uuid.UUID
+	// spectator clients receive game state but do not control a player.
+	spectator bool
+	// disconnectedAt is set when the stream breaks, and cleared when the
+	// client resumes.
+	disconnectedAt time.Time
+	lastResync     time.Time
+	// sequence is the sequence of the last response sent on the stream.
+	sequence uint64
+	// lastInput is the sequence of the last request received on the stream.
+	lastInput uint64
+	// lobby contains the player's choices while waiting for the match.
+	lobby lobbyMember
+}
+
+// stop ends the client's stream with an error. Nothing happens if the stream
+// is not active.
+func (c *client) stop(err error) {
+	select {
+	case c.done <- err:
+	default:
+	}
 }
 
 // GameServer is used to stream game information with clients.
@@ -40,6 +81,18 @@ type GameServer struct {
 	clients  map[uuid.UUID]*client
 	mu       sync.RWMutex
 	password string
+	// tick is the game tick of the latest changes, protected by mu.
+	tick         uint64
+	subscription *backend.Subscription
+	done         chan struct{}
+	stopOnce     sync.Once
+	// onEmpty is called when the last client leaves.
+	onEmpty func()
+	// onRoundOver is called with the names of players with clients, grouped
+	// by the place they tied for, when a round is over.
+	onRoundOver func(placements [][]string)
+	// inLobby is set while players wait for the match to start.
+	inLobby bool
 }
 
 // NewGameServer constructs a new game server struct.
@@ -48,21 +101,74 @@ func NewGameServer(game *backend.Game, password string) *GameServer {
 		game:     game,
 		clients:  make(map[uuid.UUID]*client),
 		password: password,
+		done:     make(chan struct{}),
 	}
 	server.watchChanges()
 	server.watchTimeout()
+	server.watchSnapshots()
 	return server
 }
 
+// Stop ends all client streams and stops sending game changes. The game
+// itself is not stopped.
+func (s *GameServer) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+		s.subscription.Unsubscribe()
+		s.mu.RLock()
+		for _, currentClient := range s.clients {
+			currentClient.stop(errServerStopped)
+		}
+		s.mu.RUnlock()
+	})
+}
+
 func (s *GameServer) removeClient(id uuid.UUID) {
 	s.mu.Lock()
 	delete(s.clients, id)
 	s.mu.Unlock()
+	s.clientRemoved()
+}
+
+// clientRemoved updates the lobby, and calls onEmpty if the last client has
+// left.
+func (s *GameServer) clientRemoved() {
+	s.mu.RLock()
+	empty := len(s.clients) == 0
+	inLobby := s.inLobby
+	s.mu.RUnlock()
+	if inLobby {
+		s.broadcastLobbyState()
+	}
+	if empty && s.onEmpty != nil {
+		s.onEmpty()
+	}
+}
+
+// hasClient checks if a token belongs to one of the server's clients.
+func (s *GameServer) hasClient(token uuid.UUID) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.clients[token]
+	return ok
+}
+
+// countClients counts the connected players or spectators.
+func (s *GameServer) countClients(spectator bool) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	count := 0
+	for _, currentClient := range s.clients {
+		if currentClient.spectator == spectator {
+			count++
+		}
+	}
+	return count
 }
 
 func (s *GameServer) removePlayer(playerID uuid.UUID) {
 	s.game.Mu.Lock()
-	s.game.RemoveEntity(playerID)
+	s.game.RemovePlayer(playerID)
 	s.game.Mu.Unlock()
 
 	resp := proto.Response{
@@ -75,15 +181,24 @@ func (s *GameServer) removePlayer(playerID uuid.UUID) {
 	s.broadcast(&resp)
 }
 
-func (s *GameServer) getClientFromContext(ctx context.Context) (*client, error) {
-	headers, ok := metadata.FromIncomingContext(ctx)
+// getTokenFromContext reads the client token from the stream's metadata.
+func getTokenFromContext(ctx context.Context) (uuid.UUID, error) {
+	headers, _ := metadata.FromIncomingContext(ctx)
 	tokenRaw := headers["authorization"]
 	if len(tokenRaw) == 0 {
-		return nil, errors.New("no token provided")
+		return uuid.Nil, errors.New("no token provided")
 	}
 	token, err := uuid.Parse(tokenRaw[0])
 	if err != nil {
-		return nil, errors.New("cannot parse token")
+		return uuid.Nil, errors.New("cannot parse token")
+	}
+	return token, nil
+}
+
+func (s *GameServer) getClientFromContext(ctx context.Context) (*client, error) {
+	token, err := getTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
 	}
 	s.mu.RLock()
 	currentClient, ok := s.clients[token]
@@ -101,58 +216,242 @@ func (s *GameServer) Stream(srv proto.Game_StreamServer) error {
 	if err != nil {
 		return err
 	}
+	// Changes made since the client connected or resumed were not sent to
+	// it, so every stream starts with the current game state.
+	snapshot := proto.Response{
+		Action: &proto.Response_Snapshot{
+			Snapshot: s.newSnapshot(true),
+		},
+	}
+	s.mu.Lock()
 	if currentClient.streamServer != nil {
+		s.mu.Unlock()
 		return errors.New("stream already active")
 	}
+	// Each stream has its own done channel, so that a broken stream can not
+	// end a resumed one.
+	done := make(chan error)
+	sendQueue := make(chan *proto.Response, sendQueueSize)
 	currentClient.streamServer = srv
+	currentClient.sendQueue = sendQueue
+	currentClient.done = done
+	currentClient.disconnectedAt = time.Time{}
+	currentClient.sequence = 0
+	currentClient.lastInput = 0
+	s.enqueue(currentClient, &snapshot)
+	s.mu.Unlock()
 
 	log.Println("start new server")
 
+	// Send queued responses until the stream ends.
+	streamDone := make(chan struct{})
+	defer close(streamDone)
+	go func() {
+		for {
+			select {
+			case resp := <-sendQueue:
+				if err := srv.Send(resp); err != nil {
+					log.Printf("%s - send error %v", currentClient.id, err)
+					select {
+					case done <- errBroadcastFailed:
+					default:
+					}
+					return
+				}
+			case <-streamDone:
+				return
+			}
+		}
+	}()
+
 	// Wait for stream requests.
 	go func() {
 		for {
 			req, err := srv.Recv()
 			if err != nil {
 				log.Printf("receive error %v", err)
-				currentClient.done <- errors.New("failed to receive request")
+				select {
+				case done <- errReceiveFailed:
+				default:
+				}
 				return
 			}
 			log.Printf("got message %+v", req)
-			currentClient.lastMessage = time.Now()
+			if !s.acceptRequest(currentClient, req.Sequence) {
+				log.Printf("%s - ignoring stale request %d", currentClient.id, req.Sequence)
+				continue
+			}
+
+			// Spectators can not perform actions, but can resync.
+			if currentClient.spectator {
+				if req.GetResync() != nil {
+					s.handleResyncRequest(req, currentClient)
+				}
+				continue
+			}
+
+			// Players in the lobby have no player to control yet.
+			if s.isInLobby() {
+				switch req.GetAction().(type) {
+				case *proto.Request_UpdateLobby:
+					s.handleUpdateLobbyRequest(req, currentClient)
+				case *proto.Request_StartMatch:
+					s.handleStartMatchRequest(req, currentClient)
+				case *proto.Request_Resync:
+					s.handleResyncRequest(req, currentClient)
+				}
+				continue
+			}
 
 			switch req.GetAction().(type) {
 			case *proto.Request_Move:
 				s.handleMoveRequest(req, currentClient)
 			case *proto.Request_Laser:
 				s.handleLaserRequest(req, currentClient)
+			case *proto.Request_SwitchWeapon:
+				s.handleSwitchWeaponRequest(req, currentClient)
+			case *proto.Request_Resync:
+				s.handleResyncRequest(req, currentClient)
 			}
 		}
 	}()
 
 	// Wait for stream to be done.
 	var doneError error
+	canResume := false
 	select {
 	case <-ctx.Done():
 		doneError = ctx.Err()
-	case doneError = <-currentClient.done:
+		canResume = true
+	case doneError = <-done:
+		canResume = doneError == errReceiveFailed || doneError == errBroadcastFailed
 	}
 	log.Printf(`stream done with error "%v"`, doneError)
 
+	// Keep the player around if the connection broke, as the client may be
+	// able to resume.
+	if canResume {
+		log.Printf("%s - waiting for client to resume", currentClient.id)
+		s.disconnectClient(currentClient)
+		return doneError
+	}
+
 	log.Printf("%s - removing client", currentClient.id)
+	if !currentClient.spectator {
+		s.removePlayer(currentClient.playerID)
+	}
 	s.removeClient(currentClient.id)
-	s.removePlayer(currentClient.playerID)
 
 	return doneError
 }
 
-func (s *GameServer) Connect(ctx context.Context, req *proto.ConnectRequest) (*proto.ConnectResponse, error) {
-	if len(s.clients) >= maxClients {
-		return nil, errors.New("The server is full")
+// acceptRequest records that a client sent a request, and determines if the
+// request should be handled. Requests that arrive late or more than once are
+// ignored.
+func (s *GameServer) acceptRequest(currentClient *client, sequence uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	currentClient.lastMessage = time.Now()
+	if sequence <= currentClient.lastInput {
+		return false
 	}
+	currentClient.lastInput = sequence
+	return true
+}
 
-	playerID, err := uuid.Parse(req.Id)
+// disconnectClient marks the client as disconnected, and removes it if it
+// does not resume within resumeGracePeriod.
+func (s *GameServer) disconnectClient(currentClient *client) {
+	s.mu.Lock()
+	currentClient.streamServer = nil
+	currentClient.sendQueue = nil
+	currentClient.disconnectedAt = time.Now()
+	s.mu.Unlock()
+	time.AfterFunc(resumeGracePeriod, func() {
+		s.expireClient(currentClient)
+	})
+}
+
+// expireClient removes a client and their player if the client is still
+// disconnected after the grace period.
+func (s *GameServer) expireClient(currentClient *client) {
+	s.mu.Lock()
+	disconnectedAt := currentClient.disconnectedAt
+	if disconnectedAt.IsZero() || time.Since(disconnectedAt) < resumeGracePeriod {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.clients, currentClient.id)
+	s.mu.Unlock()
+
+	log.Printf("%s - removing client that did not resume", currentClient.id)
+	if !currentClient.spectator {
+		s.removePlayer(currentClient.playerID)
+	}
+	s.clientRemoved()
+}
+
+// Leave removes a client and their player from the game. Clients should call
+// this when they quit, so that their player does not wait to be resumed.
+func (s *GameServer) Leave(ctx context.Context, req *proto.LeaveRequest) (*proto.LeaveResponse, error) {
+	token, err := uuid.Parse(req.Token)
 	if err != nil {
-		return nil, err
+		return nil, errors.New("cannot parse token")
+	}
+	s.mu.RLock()
+	currentClient, ok := s.clients[token]
+	active := ok && currentClient.streamServer != nil
+	s.mu.RUnlock()
+	if !ok {
+		return nil, errors.New("token not recognized")
+	}
+
+	log.Printf("%s - client left", token)
+	// Active streams remove the client when they end.
+	if active {
+		currentClient.stop(errClientLeft)
+		return &proto.LeaveResponse{}, nil
+	}
+	if !currentClient.spectator {
+		s.removePlayer(currentClient.playerID)
+	}
+	s.removeClient(currentClient.id)
+	return &proto.LeaveResponse{}, nil
+}
+
+// Resume lets a client whose stream broke continue with the same player. The
+// client receives the current game state, and can then open a new stream
+// with the same token.
+func (s *GameServer) Resume(ctx context.Context, req *proto.ResumeRequest) (*proto.ConnectResponse, error) {
+	token, err := uuid.Parse(req.Token)
+	if err != nil {
+		return nil, errors.New("cannot parse token")
+	}
+	s.mu.Lock()
+	currentClient, ok := s.clients[token]
+	if !ok {
+		s.mu.Unlock()
+		return nil, errors.New("token not recognized")
+	}
+	if currentClient.streamServer != nil {
+		s.mu.Unlock()
+		return nil, errors.New("stream already active")
+	}
+	currentClient.lastMessage = time.Now()
+	s.mu.Unlock()
+
+	log.Printf("%s - resuming client", token)
+	return s.newConnectResponse(token), nil
+}
+
+func (s *GameServer) Connect(ctx context.Context, req *proto.ConnectRequest) (*proto.ConnectResponse, error) {
+	// Spectators have a separate limit so they do not take player slots.
+	if req.Spectator {
+		if s.countClients(true) >= maxSpectators {
+			return nil, errors.New("The server has too many spectators")
+		}
+	} else if s.countClients(false) >= maxClients {
+		return nil, errors.New("The server is full")
 	}
 
 	// Exit as early as possible if password is wrong.
@@ -160,47 +459,63 @@ func (s *GameServer) Connect(ctx context.Context, req *proto.ConnectRequest) (*p
 		return nil, errors.New("invalid password provided")
 	}
 
+	// Spectators receive game state without adding a player.
+	if req.Spectator {
+		token := s.addClient(uuid.Nil, true)
+		return s.newConnectResponse(token), nil
+	}
+
+	playerID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if player already exists.
 	s.game.Mu.RLock()
-	if s.game.GetEntity(playerID) != nil {
+	duplicate := s.game.GetEntity(playerID) != nil
+	s.game.Mu.RUnlock()
+	if duplicate {
 		return nil, errors.New("duplicate player ID provided")
 	}
-	s.game.Mu.RUnlock()
 
 	re := regexp.MustCompile("^[a-zA-Z0-9]+$")
 	if !re.MatchString(req.Name) {
 		return nil, errors.New("invalid name provided")
 	}
 	icon, _ := utf8.DecodeRuneInString(strings.ToUpper(req.Name))
+	// Use the requested team if possible, otherwise the game mode will
+	// choose a team.
+	team := proto.GetBackendTeam(req.Team)
 
-	// Choose a random spawn point.
-	spawnPoints := s.game.GetMapByType()[backend.MapTypeSpawn]
-	rand.Seed(time.Now().Unix())
-	i := rand.Int() % len(spawnPoints)
-	startCoordinate := spawnPoints[i]
+	// Players wait in the lobby until the match starts.
+	s.mu.Lock()
+	if s.inLobby {
+		for _, currentClient := range s.clients {
+			if currentClient.playerID == playerID {
+				s.mu.Unlock()
+				return nil, errors.New("duplicate player ID provided")
+			}
+		}
+		lobbyClient := newClient(playerID, false)
+		lobbyClient.lobby = lobbyMember{
+			name:     req.Name,
+			icon:     icon,
+			team:     team,
+			joinedAt: time.Now(),
+		}
+		s.clients[lobbyClient.id] = lobbyClient
+		s.mu.Unlock()
+		s.broadcastLobbyState()
+		return s.newConnectResponse(lobbyClient.id), nil
+	}
+	s.mu.Unlock()
 
 	// Add the player.
-	player := &backend.Player{
-		Name:            req.Name,
-		Icon:            icon,
-		IdentifierBase:  backend.IdentifierBase{UUID: playerID},
-		CurrentPosition: startCoordinate,
-	}
 	s.game.Mu.Lock()
-	s.game.AddEntity(player)
+	player := s.newPlayer(playerID, req.Name, icon, team)
+	s.game.AddPlayer(player)
 	s.game.Mu.Unlock()
 
-	// Build a slice of current entities.
-	s.game.Mu.RLock()
-	entities := make([]*proto.Entity, 0)
-	for _, entity := range s.game.Entities {
-		protoEntity := proto.GetProtoEntity(entity)
-		if protoEntity != nil {
-			entities = append(entities, protoEntity)
-		}
-	}
-	s.game.Mu.RUnlock()
-
 	// Inform all other clients of the new player.
 	resp := proto.Response{
 		Action: &proto.Response_AddEntity{
@@ -211,44 +526,146 @@ func (s *GameServer) Connect(ctx context.Context, req *proto.ConnectRequest) (*p
 	}
 	s.broadcast(&resp)
 
-	// Add the new client.
+	token := s.addClient(playerID, false)
+	return s.newConnectResponse(token), nil
+}
+
+// addClient adds a new client and returns its token.
+func (s *GameServer) addClient(playerID uuid.UUID, spectator bool) uuid.UUID {
 	s.mu.Lock()
-	token := uuid.New()
-	s.clients[token] = &client{
-		id:          token,
+	defer s.mu.Unlock()
+	newClient := newClient(playerID, spectator)
+	s.clients[newClient.id] = newClient
+	return newClient.id
+}
+
+// newClient constructs a new client struct with a new token.
+func newClient(playerID uuid.UUID, spectator bool) *client {
+	return &client{
+		id:          uuid.New(),
 		playerID:    playerID,
 		done:        make(chan error),
 		lastMessage: time.Now(),
+		spectator:   spectator,
 	}
-	s.mu.Unlock()
+}
 
-	return &proto.ConnectResponse{
-		Token:    token.String(),
+// newConnectResponse builds a response containing the current game state,
+// which is used when clients connect or resume.
+func (s *GameServer) newConnectResponse(token uuid.UUID) *proto.ConnectResponse {
+	snapshot := s.newSnapshot(true)
+	resp := &proto.ConnectResponse{
+		Token:     token.String(),
+		Entities:  snapshot.Entities,
+		Map:       snapshot.Map,
+		Hill:      snapshot.Hill,
+		Scores:    snapshot.Scores,
+		RoundOver: snapshot.RoundOver,
+		Tick:      snapshot.Tick,
+	}
+	s.mu.RLock()
+	if s.inLobby {
+		resp.Lobby = s.getProtoLobbyState()
+	}
+	s.mu.RUnlock()
+	return resp
+}
+
+// newSnapshot captures the full game state. The map is only included if
+// requested, otherwise clients can compare map IDs.
+func (s *GameServer) newSnapshot(includeMap bool) *proto.Snapshot {
+	s.game.Mu.RLock()
+	defer s.game.Mu.RUnlock()
+	entities := make([]*proto.Entity, 0)
+	for _, entity := range s.game.Entities {
+		protoEntity := proto.GetProtoEntity(entity)
+		if protoEntity != nil {
+			entities = append(entities, protoEntity)
+		}
+	}
+	scores := make(map[string]int32)
+	for id, score := range s.game.Score {
+		scores[id.String()] = int32(score)
+	}
+	gameMap := s.game.GetMap()
+	snapshot := &proto.Snapshot{
+		Tick:     s.game.Tick,
 		Entities: entities,
-	}, nil
+		Scores:   scores,
+		MapId:    gameMap.ID(),
+		Hill:     proto.GetProtoHill(s.game.Hill, s.game.HillMovesAt),
+	}
+	if includeMap {
+		snapshot.Map = proto.GetProtoMap(gameMap)
+	}
+	if s.game.WaitForRound {
+		snapshot.RoundOver = s.getProtoRoundOver()
+	}
+	return snapshot
+}
+
+// watchSnapshots periodically sends the full game state to all clients, which
+// corrects any drift caused by lost changes.
+func (s *GameServer) watchSnapshots() {
+	snapshotTicker := time.NewTicker(snapshotInterval)
+	go func() {
+		defer snapshotTicker.Stop()
+		for {
+			select {
+			case <-snapshotTicker.C:
+			case <-s.done:
+				return
+			}
+			resp := proto.Response{
+				Action: &proto.Response_Snapshot{
+					Snapshot: s.newSnapshot(false),
+				},
+			}
+			s.broadcast(&resp)
+		}
+	}()
 }
 
 func (s *GameServer) watchTimeout() {
 	timeoutTicker := time.NewTicker(1 * time.Minute)
 	go func() {
 		for {
+			s.mu.RLock()
 			for _, client := range s.clients {
+				// Spectators never send messages, so they can not idle.
+				// Disconnected clients are removed by expireClient.
+				if client.spectator || client.streamServer == nil {
+					continue
+				}
 				if time.Now().Sub(client.lastMessage).Minutes() > clientTimeout {
-					client.done <- errors.New("you have been timed out")
-					return
+					client.stop(errors.New("you have been timed out"))
 				}
 			}
-			<-timeoutTicker.C
+			s.mu.RUnlock()
+			select {
+			case <-timeoutTicker.C:
+			case <-s.done:
+				timeoutTicker.Stop()
+				return
+			}
 		}
 	}()
 }
 
 // WatchChanges waits for new game engine changes and broadcasts to clients.
 func (s *GameServer) watchChanges() {
+	s.subscription = s.game.ChangeBus.Subscribe(changeQueueSize, backend.OverflowBlock)
 	go func() {
-		for {
-			change := <-s.game.ChangeChannel
+		for change := range s.subscription.Changes {
 			switch change.(type) {
+			case backend.TickChange:
+				change := change.(backend.TickChange)
+				s.mu.Lock()
+				s.tick = change.Tick
+				s.mu.Unlock()
+			case backend.InputAckChange:
+				change := change.(backend.InputAckChange)
+				s.handleInputAckChange(change)
 			case backend.MoveChange:
 				change := change.(backend.MoveChange)
 				s.handleMoveChange(change)
@@ -258,33 +675,94 @@ func (s *GameServer) watchChanges() {
 			case backend.RemoveEntityChange:
 				change := change.(backend.RemoveEntityChange)
 				s.handleRemoveEntityChange(change)
+			case backend.PickupChange:
+				change := change.(backend.PickupChange)
+				s.handlePickupChange(change)
+			case backend.PlayerDamageChange:
+				change := change.(backend.PlayerDamageChange)
+				s.handlePlayerDamageChange(change)
 			case backend.PlayerRespawnChange:
 				change := change.(backend.PlayerRespawnChange)
 				s.handlePlayerRespawnChange(change)
+			case backend.PlayerDeathChange:
+				change := change.(backend.PlayerDeathChange)
+				s.handlePlayerDeathChange(change)
+			case backend.ScoreChange:
+				change := change.(backend.ScoreChange)
+				s.handleScoreChange(change)
+			case backend.FlagPickupChange:
+				change := change.(backend.FlagPickupChange)
+				s.handleFlagPickupChange(change)
+			case backend.FlagDropChange:
+				change := change.(backend.FlagDropChange)
+				s.handleFlagDropChange(change)
+			case backend.FlagReturnChange:
+				change := change.(backend.FlagReturnChange)
+				s.handleFlagReturnChange(change)
+			case backend.FlagCaptureChange:
+				change := change.(backend.FlagCaptureChange)
+				s.handleFlagCaptureChange(change)
+			case backend.HillChange:
+				change := change.(backend.HillChange)
+				s.handleHillChange(change)
 			case backend.RoundOverChange:
 				change := change.(backend.RoundOverChange)
 				s.handleRoundOverChange(change)
 			case backend.RoundStartChange:
 				change := change.(backend.RoundStartChange)
 				s.handleRoundStartChange(change)
+			case backend.MapChange:
+				change := change.(backend.MapChange)
+				s.handleMapChange(change)
 			}
 		}
 	}()
 }
 
+// stamp sets the sequence, tick, and time of a response before it is sent to
+// a client. The caller must hold the server lock.
+func (s *GameServer) stamp(currentClient *client, resp *proto.Response) {
+	currentClient.sequence++
+	resp.Sequence = currentClient.sequence
+	resp.Tick = s.tick
+	resp.ServerTime, _ = ptypes.TimestampProto(s.game.Clock.Now())
+}
+
+// enqueue stamps a copy of a response and queues it to be sent to a client.
+// If the client's queue is full, the client can not keep up, and its stream
+// is ended so that it resumes with the current game state instead of holding
+// up the game. Returns false if the response was not queued. The caller must
+// hold the server lock.
+func (s *GameServer) enqueue(currentClient *client, resp *proto.Response) bool {
+	if currentClient.sendQueue == nil {
+		return false
+	}
+	stamped := *resp
+	s.stamp(currentClient, &stamped)
+	select {
+	case currentClient.sendQueue <- &stamped:
+		return true
+	default:
+		log.Printf("%s - send queue is full", currentClient.id)
+		currentClient.stop(errBroadcastFailed)
+		return false
+	}
+}
+
+// send sends a response to a single client.
+func (s *GameServer) send(currentClient *client, resp *proto.Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enqueue(currentClient, resp)
+}
+
 // broadcast sends a response to all clients.
 func (s *GameServer) broadcast(resp *proto.Response) {
 	s.mu.Lock()
 	for id, currentClient := range s.clients {
-		if currentClient.streamServer == nil {
-			continue
-		}
-		if err := currentClient.streamServer.Send(resp); err != nil {
-			log.Printf("%s - broadcast error %v", id, err)
-			currentClient.done <- errors.New("failed to broadcast message")
-			continue
+		if s.enqueue(currentClient, resp) {
+			log.Printf("%s - broadcasted %+v", resp, id)
 		}
-		log.Printf("%s - broadcasted %+v", resp, id)
 	}
 	s.mu.Unlock()
 }
@@ -292,34 +770,106 @@ func (s *GameServer) broadcast(resp *proto.Response) {
 // handleMoveRequest makes a request to the game engine to move a player.
 func (s *GameServer) handleMoveRequest(req *proto.Request, currentClient *client) {
 	move := req.GetMove()
-	s.game.ActionChannel <- backend.MoveAction{
+	s.queueAction(req, currentClient, backend.MoveAction{
 		ID:        currentClient.playerID,
 		Direction: proto.GetBackendDirection(move.Direction),
-		Created:   time.Now(),
-	}
+		Created:   s.game.Clock.Now(),
+	})
 }
 
 func (s *GameServer) handleLaserRequest(req *proto.Request, currentClient *client) {
 	laser := req.GetLaser()
 	id, err := uuid.Parse(laser.Id)
 	if err != nil {
-		currentClient.done <- errors.New("invalid laser ID provided")
+		currentClient.stop(errors.New("invalid laser ID provided"))
 		return
 	}
 	s.game.Mu.RLock()
-	if s.game.GetEntity(id) != nil {
-		currentClient.done <- errors.New("duplicate laser ID provided")
+	duplicate := s.game.GetEntity(id) != nil
+	s.game.Mu.RUnlock()
+	if duplicate {
+		currentClient.stop(errors.New("duplicate laser ID provided"))
 		return
 	}
-	s.game.Mu.RUnlock()
-	s.game.ActionChannel <- backend.LaserAction{
+	// Clients that do not send a view time get no latency compensation.
+	viewTime, err := ptypes.Timestamp(req.ViewTime)
+	if err != nil {
+		viewTime = time.Time{}
+	}
+	s.queueAction(req, currentClient, backend.LaserAction{
 		OwnerID:   currentClient.playerID,
 		ID:        id,
 		Direction: proto.GetBackendDirection(laser.Direction),
-		Created:   time.Now(),
+		Created:   s.game.Clock.Now(),
+		ViewTime:  viewTime,
+	})
+}
+
+func (s *GameServer) handleSwitchWeaponRequest(req *proto.Request, currentClient *client) {
+	switchWeapon := req.GetSwitchWeapon()
+	s.queueAction(req, currentClient, backend.SwitchWeaponAction{
+		ID:      currentClient.playerID,
+		Weapon:  proto.GetBackendWeapon(switchWeapon.Weapon),
+		Created: s.game.Clock.Now(),
+	})
+}
+
+// queueAction sends an action requested by a client to the game engine,
+// which acknowledges the request once the action has been processed.
+func (s *GameServer) queueAction(req *proto.Request, currentClient *client, action backend.Action) {
+	s.game.ActionChannel <- backend.InputAction{
+		Action:   action,
+		PlayerID: currentClient.playerID,
+		Sequence: req.Sequence,
 	}
 }
 
+// handleResyncRequest sends the full game state, including the map, to a
+// client that has detected that it is out of sync.
+func (s *GameServer) handleResyncRequest(req *proto.Request, currentClient *client) {
+	s.mu.Lock()
+	throttled := time.Since(currentClient.lastResync) < resyncThrottle
+	if !throttled {
+		currentClient.lastResync = time.Now()
+	}
+	s.mu.Unlock()
+	if throttled {
+		return
+	}
+	resp := proto.Response{
+		Action: &proto.Response_Snapshot{
+			Snapshot: s.newSnapshot(true),
+		},
+	}
+	s.send(currentClient, &resp)
+}
+
+// handleInputAckChange tells a client which of their requests have been
+// processed.
+func (s *GameServer) handleInputAckChange(change backend.InputAckChange) {
+	var owner *client
+	s.mu.RLock()
+	for _, currentClient := range s.clients {
+		if !currentClient.spectator && currentClient.playerID == change.PlayerID {
+			owner = currentClient
+			break
+		}
+	}
+	s.mu.RUnlock()
+	if owner == nil {
+		return
+	}
+	resp := proto.Response{
+		Action: &proto.Response_InputAck{
+			InputAck: &proto.InputAck{
+				Sequence: change.Sequence,
+				Position: proto.GetProtoCoordinate(change.Position),
+			},
+		},
+	}
+	s.send(owner, &resp)
+}
+
 func (s *GameServer) handleMoveChange(change backend.MoveChange) {
 	resp := proto.Response{
 		Action: &proto.Response_UpdateEntity{
@@ -353,6 +903,34 @@ func (s *GameServer) handleRemoveEntityChange(change backend.RemoveEntityChange)
 	s.broadcast(&resp)
 }
 
+func (s *GameServer) handlePickupChange(change backend.PickupChange) {
+	s.game.Mu.RLock()
+	player := proto.GetProtoPlayer(change.Player)
+	s.game.Mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_CollectPickup{
+			CollectPickup: &proto.CollectPickup{
+				Player:   player,
+				PickupId: change.Pickup.ID().String(),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
+func (s *GameServer) handlePlayerDamageChange(change backend.PlayerDamageChange) {
+	resp := proto.Response{
+		Action: &proto.Response_PlayerDamage{
+			PlayerDamage: &proto.PlayerDamage{
+				PlayerId:    change.Player.ID().String(),
+				Health:      int32(change.Health),
+				DamagedById: change.DamagedByID.String(),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
 func (s *GameServer) handlePlayerRespawnChange(change backend.PlayerRespawnChange) {
 	resp := proto.Response{
 		Action: &proto.Response_PlayerRespawn{
@@ -365,22 +943,155 @@ func (s *GameServer) handlePlayerRespawnChange(change backend.PlayerRespawnChang
 	s.broadcast(&resp)
 }
 
+func (s *GameServer) handlePlayerDeathChange(change backend.PlayerDeathChange) {
+	resp := proto.Response{
+		Action: &proto.Response_PlayerDeath{
+			PlayerDeath: &proto.PlayerDeath{
+				PlayerId:   change.Player.ID().String(),
+				KilledById: change.KilledByID.String(),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
+func (s *GameServer) handleScoreChange(change backend.ScoreChange) {
+	resp := proto.Response{
+		Action: &proto.Response_UpdateScore{
+			UpdateScore: &proto.UpdateScore{
+				PlayerId: change.PlayerID.String(),
+				Score:    int32(change.Score),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
+func (s *GameServer) handleFlagPickupChange(change backend.FlagPickupChange) {
+	resp := proto.Response{
+		Action: &proto.Response_PickupFlag{
+			PickupFlag: &proto.PickupFlag{
+				FlagId:   change.Flag.ID().String(),
+				PlayerId: change.Player.ID().String(),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
+func (s *GameServer) handleFlagDropChange(change backend.FlagDropChange) {
+	s.game.Mu.RLock()
+	flag := proto.GetProtoFlag(change.Flag)
+	s.game.Mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_DropFlag{
+			DropFlag: &proto.DropFlag{
+				Flag: flag,
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
+func (s *GameServer) handleFlagReturnChange(change backend.FlagReturnChange) {
+	s.game.Mu.RLock()
+	flag := proto.GetProtoFlag(change.Flag)
+	s.game.Mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_ReturnFlag{
+			ReturnFlag: &proto.ReturnFlag{
+				Flag:     flag,
+				PlayerId: change.Player.ID().String(),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
+func (s *GameServer) handleFlagCaptureChange(change backend.FlagCaptureChange) {
+	s.game.Mu.RLock()
+	flag := proto.GetProtoFlag(change.Flag)
+	s.game.Mu.RUnlock()
+	resp := proto.Response{
+		Action: &proto.Response_CaptureFlag{
+			CaptureFlag: &proto.CaptureFlag{
+				Flag:     flag,
+				PlayerId: change.Player.ID().String(),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
+func (s *GameServer) handleHillChange(change backend.HillChange) {
+	resp := proto.Response{
+		Action: &proto.Response_MoveHill{
+			MoveHill: &proto.MoveHill{
+				Hill: proto.GetProtoHill(change.Hill, change.MovesAt),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
+
 func (s *GameServer) handleRoundOverChange(change backend.RoundOverChange) {
+	// Bots have no client, and are left out of the placements.
+	s.mu.RLock()
+	hasClient := make(map[uuid.UUID]bool)
+	for _, currentClient := range s.clients {
+		if !currentClient.spectator {
+			hasClient[currentClient.playerID] = true
+		}
+	}
+	s.mu.RUnlock()
+
 	s.game.Mu.RLock()
-	defer s.game.Mu.RUnlock()
-	timestamp, err := ptypes.TimestampProto(s.game.NewRoundAt)
-	if err != nil {
-		log.Fatalf("unable to parse new round timestamp %v", s.game.NewRoundAt)
+	placements := make([][]string, 0)
+	for _, group := range s.game.RoundPlacements {
+		names := make([]string, 0)
+		for _, id := range group {
+			player, ok := s.game.GetEntity(id).(*backend.Player)
+			if ok && hasClient[id] {
+				names = append(names, player.Name)
+			}
+		}
+		if len(names) > 0 {
+			placements = append(placements, names)
+		}
 	}
 	resp := proto.Response{
 		Action: &proto.Response_RoundOver{
-			RoundOver: &proto.RoundOver{
-				RoundWinnerId: s.game.RoundWinner.String(),
-				NewRoundAt:    timestamp,
-			},
+			RoundOver: s.getProtoRoundOver(),
 		},
 	}
+	s.game.Mu.RUnlock()
 	s.broadcast(&resp)
+
+	if s.onRoundOver != nil {
+		s.onRoundOver(placements)
+	}
+}
+
+// getProtoRoundOver describes the round that is over. The game lock must be
+// held by the caller.
+func (s *GameServer) getProtoRoundOver() *proto.RoundOver {
+	timestamp, err := ptypes.TimestampProto(s.game.NewRoundAt)
+	if err != nil {
+		log.Fatalf("unable to parse new round timestamp %v", s.game.NewRoundAt)
+	}
+	placements := make([]*proto.Placement, len(s.game.RoundPlacements))
+	for i, group := range s.game.RoundPlacements {
+		placements[i] = &proto.Placement{PlayerIds: make([]string, len(group))}
+		for j, id := range group {
+			placements[i].PlayerIds[j] = id.String()
+		}
+	}
+	return &proto.RoundOver{
+		RoundWinnerId:   s.game.RoundWinner.String(),
+		NewRoundAt:      timestamp,
+		RoundWinnerTeam: proto.GetProtoTeam(s.game.RoundWinnerTeam),
+		Placements:      placements,
+	}
 }
 
 func (s *GameServer) handleRoundStartChange(change backend.RoundStartChange) {
@@ -403,3 +1114,14 @@ func (s *GameServer) handleRoundStartChange(change backend.RoundStartChange) {
 	}
 	s.broadcast(&resp)
 }
+
+func (s *GameServer) handleMapChange(change backend.MapChange) {
+	resp := proto.Response{
+		Action: &proto.Response_ChangeMap{
+			ChangeMap: &proto.ChangeMap{
+				Map: proto.GetProtoMap(change.Map),
+			},
+		},
+	}
+	s.broadcast(&resp)
+}
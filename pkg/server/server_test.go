@@ -0,0 +1,33 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mortenson/grpc-game-example/pkg/backend"
+)
+
+func TestAcceptRequestDropsStaleSequences(t *testing.T) {
+	s := NewGameServer(backend.NewGame(), "")
+	defer s.Stop()
+	currentClient := &client{id: uuid.New()}
+
+	for _, test := range []struct {
+		sequence uint64
+		accepted bool
+	}{
+		{sequence: 1, accepted: true},
+		{sequence: 1, accepted: false},
+		{sequence: 3, accepted: true},
+		{sequence: 2, accepted: false},
+		{sequence: 3, accepted: false},
+		{sequence: 4, accepted: true},
+	} {
+		if s.acceptRequest(currentClient, test.sequence) != test.accepted {
+			t.Errorf("sequence %d: expected accepted to be %v", test.sequence, test.accepted)
+		}
+	}
+	if currentClient.lastMessage.IsZero() {
+		t.Errorf("expected dropped and accepted requests to count as messages")
+	}
+}
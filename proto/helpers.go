@@ -2,6 +2,7 @@ package proto
 
 import (
 	"log"
+	"time"
 	"unicode/utf8"
 
 	"github.com/golang/protobuf/ptypes"
@@ -39,6 +40,98 @@ func GetProtoDirection(direction backend.Direction) Direction {
 	return protoDirection
 }
 
+func GetBackendWeapon(protoWeapon Weapon) backend.WeaponType {
+	weapon := backend.WeaponLaser
+	switch protoWeapon {
+	case Weapon_SHOTGUN:
+		weapon = backend.WeaponShotgun
+	case Weapon_HEAVY:
+		weapon = backend.WeaponHeavy
+	case Weapon_SNIPER:
+		weapon = backend.WeaponSniper
+	}
+	return weapon
+}
+
+func GetProtoWeapon(weapon backend.WeaponType) Weapon {
+	protoWeapon := Weapon_LASER
+	switch weapon {
+	case backend.WeaponShotgun:
+		protoWeapon = Weapon_SHOTGUN
+	case backend.WeaponHeavy:
+		protoWeapon = Weapon_HEAVY
+	case backend.WeaponSniper:
+		protoWeapon = Weapon_SNIPER
+	}
+	return protoWeapon
+}
+
+func GetBackendPickupKind(protoKind PickupKind) backend.PickupKind {
+	kind := backend.PickupHealth
+	switch protoKind {
+	case PickupKind_SPEED_BOOST:
+		kind = backend.PickupSpeedBoost
+	case PickupKind_RAPID_FIRE:
+		kind = backend.PickupRapidFire
+	case PickupKind_SHIELD:
+		kind = backend.PickupShield
+	}
+	return kind
+}
+
+func GetProtoPickupKind(kind backend.PickupKind) PickupKind {
+	protoKind := PickupKind_HEALTH
+	switch kind {
+	case backend.PickupSpeedBoost:
+		protoKind = PickupKind_SPEED_BOOST
+	case backend.PickupRapidFire:
+		protoKind = PickupKind_RAPID_FIRE
+	case backend.PickupShield:
+		protoKind = PickupKind_SHIELD
+	}
+	return protoKind
+}
+
+func GetBackendTeam(protoTeam Team) backend.Team {
+	team := backend.TeamNone
+	switch protoTeam {
+	case Team_RED:
+		team = backend.TeamRed
+	case Team_BLUE:
+		team = backend.TeamBlue
+	}
+	return team
+}
+
+func GetProtoTeam(team backend.Team) Team {
+	protoTeam := Team_NO_TEAM
+	switch team {
+	case backend.TeamRed:
+		protoTeam = Team_RED
+	case backend.TeamBlue:
+		protoTeam = Team_BLUE
+	}
+	return protoTeam
+}
+
+func GetBackendPlayerState(protoState PlayerState) backend.PlayerState {
+	state := backend.PlayerAlive
+	switch protoState {
+	case PlayerState_DEAD:
+		state = backend.PlayerDead
+	}
+	return state
+}
+
+func GetProtoPlayerState(state backend.PlayerState) PlayerState {
+	protoState := PlayerState_ALIVE
+	switch state {
+	case backend.PlayerDead:
+		protoState = PlayerState_DEAD
+	}
+	return protoState
+}
+
 func GetBackendCoordinate(protoCoordinate *Coordinate) backend.Coordinate {
 	return backend.Coordinate{
 		X: int(protoCoordinate.X),
@@ -61,6 +154,12 @@ func GetBackendEntity(protoEntity *Entity) backend.Identifier {
 	case *Entity_Laser:
 		protoLaser := protoEntity.Entity.(*Entity_Laser).Laser
 		return GetBackendLaser(protoLaser)
+	case *Entity_Pickup:
+		protoPickup := protoEntity.Entity.(*Entity_Pickup).Pickup
+		return GetBackendPickup(protoPickup)
+	case *Entity_Flag:
+		protoFlag := protoEntity.Entity.(*Entity_Flag).Flag
+		return GetBackendFlag(protoFlag)
 	}
 	log.Printf("cannot get backend entity for %T -> %+v", protoEntity, protoEntity)
 	return nil
@@ -77,6 +176,19 @@ func GetBackendPlayer(protoPlayer *Player) *backend.Player {
 		IdentifierBase: backend.IdentifierBase{UUID: entityID},
 		Name:           protoPlayer.Name,
 		Icon:           icon,
+		Health:         int(protoPlayer.Health),
+		Weapon:         GetBackendWeapon(protoPlayer.Weapon),
+		Effects:        make(map[backend.PickupKind]time.Time),
+		Team:           GetBackendTeam(protoPlayer.Team),
+		State:          GetBackendPlayerState(protoPlayer.State),
+	}
+	for _, protoEffect := range protoPlayer.Effects {
+		expiresAt, err := ptypes.Timestamp(protoEffect.ExpiresAt)
+		if err != nil {
+			log.Printf("failed to convert proto timestamp to time: %+v", err)
+			return nil
+		}
+		player.Effects[GetBackendPickupKind(protoEffect.Kind)] = expiresAt
 	}
 	player.Move(GetBackendCoordinate(protoPlayer.Position))
 	return player
@@ -104,10 +216,47 @@ func GetBackendLaser(protoLaser *Laser) *backend.Laser {
 		Direction:       GetBackendDirection(protoLaser.Direction),
 		StartTime:       timestamp,
 		OwnerID:         ownerID,
+		Weapon:          GetBackendWeapon(protoLaser.Weapon),
 	}
 	return laser
 }
 
+func GetBackendPickup(protoPickup *Pickup) *backend.Pickup {
+	entityID, err := uuid.Parse(protoPickup.Id)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	return &backend.Pickup{
+		IdentifierBase:  backend.IdentifierBase{UUID: entityID},
+		Kind:            GetBackendPickupKind(protoPickup.Kind),
+		CurrentPosition: GetBackendCoordinate(protoPickup.Position),
+	}
+}
+
+func GetBackendFlag(protoFlag *Flag) *backend.Flag {
+	entityID, err := uuid.Parse(protoFlag.Id)
+	if err != nil {
+		log.Printf("failed to convert proto UUID: %+v", err)
+		return nil
+	}
+	carrierID := uuid.Nil
+	if protoFlag.CarrierId != "" {
+		carrierID, err = uuid.Parse(protoFlag.CarrierId)
+		if err != nil {
+			log.Printf("failed to convert proto UUID: %+v", err)
+			return nil
+		}
+	}
+	return &backend.Flag{
+		IdentifierBase:  backend.IdentifierBase{UUID: entityID},
+		Team:            GetBackendTeam(protoFlag.Team),
+		Base:            GetBackendCoordinate(protoFlag.Base),
+		CurrentPosition: GetBackendCoordinate(protoFlag.Position),
+		CarrierID:       carrierID,
+	}
+}
+
 func GetProtoEntity(entity backend.Identifier) *Entity {
 	switch entity.(type) {
 	case *backend.Player:
@@ -122,17 +271,68 @@ func GetProtoEntity(entity backend.Identifier) *Entity {
 			Laser: GetProtoLaser(laser),
 		}
 		return &Entity{Entity: &protoLaser}
+	case *backend.Pickup:
+		pickup := entity.(*backend.Pickup)
+		protoPickup := Entity_Pickup{
+			Pickup: GetProtoPickup(pickup),
+		}
+		return &Entity{Entity: &protoPickup}
+	case *backend.Flag:
+		flag := entity.(*backend.Flag)
+		protoFlag := Entity_Flag{
+			Flag: GetProtoFlag(flag),
+		}
+		return &Entity{Entity: &protoFlag}
 	}
 	log.Printf("cannot get proto entity for %T -> %+v", entity, entity)
 	return nil
 }
 
 func GetProtoPlayer(player *backend.Player) *Player {
+	effects := make([]*Effect, 0)
+	for kind, expiresAt := range player.Effects {
+		timestamp, err := ptypes.TimestampProto(expiresAt)
+		if err != nil {
+			log.Printf("failed to convert time to proto timestamp: %+v", err)
+			continue
+		}
+		effects = append(effects, &Effect{
+			Kind:      GetProtoPickupKind(kind),
+			ExpiresAt: timestamp,
+		})
+	}
 	return &Player{
 		Id:       player.ID().String(),
 		Name:     player.Name,
 		Position: GetProtoCoordinate(player.Position()),
 		Icon:     string(player.Icon),
+		Health:   int32(player.Health),
+		Weapon:   GetProtoWeapon(player.Weapon),
+		Effects:  effects,
+		Team:     GetProtoTeam(player.Team),
+		State:    GetProtoPlayerState(player.State),
+	}
+}
+
+func GetProtoPickup(pickup *backend.Pickup) *Pickup {
+	return &Pickup{
+		Id:       pickup.ID().String(),
+		Kind:     GetProtoPickupKind(pickup.Kind),
+		Position: GetProtoCoordinate(pickup.Position()),
+	}
+}
+
+func GetProtoFlag(flag *backend.Flag) *Flag {
+	carrierID := ""
+	if flag.IsCarried() {
+		carrierID = flag.CarrierID.String()
+	}
+	return &Flag{
+		Id:        flag.ID().String(),
+		Team:      GetProtoTeam(flag.Team),
+		Position:  GetProtoCoordinate(flag.Position()),
+		Base:      GetProtoCoordinate(flag.Base),
+		CarrierId: carrierID,
 	}
 }
 
@@ -148,5 +348,102 @@ func GetProtoLaser(laser *backend.Laser) *Laser {
 		InitialPosition: GetProtoCoordinate(laser.InitialPosition),
 		Direction:       GetProtoDirection(laser.Direction),
 		OwnerId:         laser.OwnerID.String(),
+		Weapon:          GetProtoWeapon(laser.Weapon),
+	}
+}
+
+func GetProtoRectangle(rect backend.Rectangle) *Rectangle {
+	return &Rectangle{
+		Position: GetProtoCoordinate(rect.Position),
+		Width:    int32(rect.Width),
+		Height:   int32(rect.Height),
+	}
+}
+
+func GetBackendRectangle(protoRect *Rectangle) backend.Rectangle {
+	return backend.Rectangle{
+		Position: GetBackendCoordinate(protoRect.Position),
+		Width:    int(protoRect.Width),
+		Height:   int(protoRect.Height),
+	}
+}
+
+// GetProtoHill converts the active hill, which may be nil if there is none.
+func GetProtoHill(hill *backend.Rectangle, movesAt time.Time) *Hill {
+	if hill == nil {
+		return &Hill{}
+	}
+	protoHill := &Hill{
+		Area: GetProtoRectangle(*hill),
+	}
+	if !movesAt.IsZero() {
+		timestamp, err := ptypes.TimestampProto(movesAt)
+		if err != nil {
+			log.Printf("failed to convert time to proto timestamp: %+v", err)
+			return protoHill
+		}
+		protoHill.MovesAt = timestamp
+	}
+	return protoHill
+}
+
+// GetBackendHill converts a proto hill to the active hill and the time it
+// moves at. The hill is nil if there is none.
+func GetBackendHill(protoHill *Hill) (*backend.Rectangle, time.Time) {
+	if protoHill == nil || protoHill.Area == nil {
+		return nil, time.Time{}
+	}
+	hill := GetBackendRectangle(protoHill.Area)
+	if protoHill.MovesAt == nil {
+		return &hill, time.Time{}
+	}
+	movesAt, err := ptypes.Timestamp(protoHill.MovesAt)
+	if err != nil {
+		log.Printf("failed to convert proto timestamp to time: %+v", err)
+		return &hill, time.Time{}
+	}
+	return &hill, movesAt
+}
+
+func GetProtoMap(gameMap *backend.Map) *Map {
+	rows := make([]string, len(gameMap.Grid))
+	for i, row := range gameMap.Grid {
+		rows[i] = string(row)
+	}
+	hills := make([]*Rectangle, len(gameMap.Hills))
+	for i, hill := range gameMap.Hills {
+		hills[i] = GetProtoRectangle(hill)
+	}
+	return &Map{
+		Name:       gameMap.Name,
+		Author:     gameMap.Author,
+		MinPlayers: int32(gameMap.MinPlayers),
+		MaxPlayers: int32(gameMap.MaxPlayers),
+		Rows:       rows,
+		Hills:      hills,
+	}
+}
+
+func GetBackendMap(protoMap *Map) *backend.Map {
+	grid := make([][]rune, len(protoMap.Rows))
+	for i, row := range protoMap.Rows {
+		grid[i] = []rune(row)
+	}
+	var hills []backend.Rectangle
+	for _, protoHill := range protoMap.Hills {
+		hills = append(hills, GetBackendRectangle(protoHill))
+	}
+	gameMap := &backend.Map{
+		Name:       protoMap.Name,
+		Author:     protoMap.Author,
+		MinPlayers: int(protoMap.MinPlayers),
+		MaxPlayers: int(protoMap.MaxPlayers),
+		Hills:      hills,
+		Grid:       grid,
+	}
+	if err := gameMap.Validate(); err != nil {
+		log.Printf("invalid map %q: %+v", protoMap.Name, err)
+		return nil
 	}
+	return gameMap
 }
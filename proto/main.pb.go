@@ -59,6 +59,121 @@ func (Direction) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_098391ad7281b52b, []int{0}
 }
 
+type Weapon int32
+
+const (
+	Weapon_LASER   Weapon = 0
+	Weapon_SHOTGUN Weapon = 1
+	Weapon_HEAVY   Weapon = 2
+	Weapon_SNIPER  Weapon = 3
+)
+
+var Weapon_name = map[int32]string{
+	0: "LASER",
+	1: "SHOTGUN",
+	2: "HEAVY",
+	3: "SNIPER",
+}
+
+var Weapon_value = map[string]int32{
+	"LASER":   0,
+	"SHOTGUN": 1,
+	"HEAVY":   2,
+	"SNIPER":  3,
+}
+
+func (x Weapon) String() string {
+	return proto.EnumName(Weapon_name, int32(x))
+}
+
+func (Weapon) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{1}
+}
+
+type PickupKind int32
+
+const (
+	PickupKind_HEALTH      PickupKind = 0
+	PickupKind_SPEED_BOOST PickupKind = 1
+	PickupKind_RAPID_FIRE  PickupKind = 2
+	PickupKind_SHIELD      PickupKind = 3
+)
+
+var PickupKind_name = map[int32]string{
+	0: "HEALTH",
+	1: "SPEED_BOOST",
+	2: "RAPID_FIRE",
+	3: "SHIELD",
+}
+
+var PickupKind_value = map[string]int32{
+	"HEALTH":      0,
+	"SPEED_BOOST": 1,
+	"RAPID_FIRE":  2,
+	"SHIELD":      3,
+}
+
+func (x PickupKind) String() string {
+	return proto.EnumName(PickupKind_name, int32(x))
+}
+
+func (PickupKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{2}
+}
+
+type Team int32
+
+const (
+	Team_NO_TEAM Team = 0
+	Team_RED     Team = 1
+	Team_BLUE    Team = 2
+)
+
+var Team_name = map[int32]string{
+	0: "NO_TEAM",
+	1: "RED",
+	2: "BLUE",
+}
+
+var Team_value = map[string]int32{
+	"NO_TEAM": 0,
+	"RED":     1,
+	"BLUE":    2,
+}
+
+func (x Team) String() string {
+	return proto.EnumName(Team_name, int32(x))
+}
+
+func (Team) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{3}
+}
+
+type PlayerState int32
+
+const (
+	PlayerState_ALIVE PlayerState = 0
+	PlayerState_DEAD  PlayerState = 1
+)
+
+var PlayerState_name = map[int32]string{
+	0: "ALIVE",
+	1: "DEAD",
+}
+
+var PlayerState_value = map[string]int32{
+	"ALIVE": 0,
+	"DEAD":  1,
+}
+
+func (x PlayerState) String() string {
+	return proto.EnumName(PlayerState_name, int32(x))
+}
+
+func (PlayerState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{4}
+}
+
 type Coordinate struct {
 	X                    int32    `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
 	Y                    int32    `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
@@ -106,11 +221,63 @@ func (m *Coordinate) GetY() int32 {
 	return 0
 }
 
+type Effect struct {
+	Kind                 PickupKind           `protobuf:"varint,1,opt,name=kind,proto3,enum=proto.PickupKind" json:"kind,omitempty"`
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Effect) Reset()         { *m = Effect{} }
+func (m *Effect) String() string { return proto.CompactTextString(m) }
+func (*Effect) ProtoMessage()    {}
+func (*Effect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{1}
+}
+
+func (m *Effect) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Effect.Unmarshal(m, b)
+}
+func (m *Effect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Effect.Marshal(b, m, deterministic)
+}
+func (m *Effect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Effect.Merge(m, src)
+}
+func (m *Effect) XXX_Size() int {
+	return xxx_messageInfo_Effect.Size(m)
+}
+func (m *Effect) XXX_DiscardUnknown() {
+	xxx_messageInfo_Effect.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Effect proto.InternalMessageInfo
+
+func (m *Effect) GetKind() PickupKind {
+	if m != nil {
+		return m.Kind
+	}
+	return PickupKind_HEALTH
+}
+
+func (m *Effect) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
 type Player struct {
 	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name                 string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Position             *Coordinate `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
 	Icon                 string      `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	Health               int32       `protobuf:"varint,5,opt,name=health,proto3" json:"health,omitempty"`
+	Weapon               Weapon      `protobuf:"varint,6,opt,name=weapon,proto3,enum=proto.Weapon" json:"weapon,omitempty"`
+	Effects              []*Effect   `protobuf:"bytes,7,rep,name=effects,proto3" json:"effects,omitempty"`
+	Team                 Team        `protobuf:"varint,8,opt,name=team,proto3,enum=proto.Team" json:"team,omitempty"`
+	State                PlayerState `protobuf:"varint,9,opt,name=state,proto3,enum=proto.PlayerState" json:"state,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -120,7 +287,7 @@ func (m *Player) Reset()         { *m = Player{} }
 func (m *Player) String() string { return proto.CompactTextString(m) }
 func (*Player) ProtoMessage()    {}
 func (*Player) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{1}
+	return fileDescriptor_098391ad7281b52b, []int{2}
 }
 
 func (m *Player) XXX_Unmarshal(b []byte) error {
@@ -169,12 +336,48 @@ func (m *Player) GetIcon() string {
 	return ""
 }
 
+func (m *Player) GetHealth() int32 {
+	if m != nil {
+		return m.Health
+	}
+	return 0
+}
+
+func (m *Player) GetWeapon() Weapon {
+	if m != nil {
+		return m.Weapon
+	}
+	return Weapon_LASER
+}
+
+func (m *Player) GetEffects() []*Effect {
+	if m != nil {
+		return m.Effects
+	}
+	return nil
+}
+
+func (m *Player) GetTeam() Team {
+	if m != nil {
+		return m.Team
+	}
+	return Team_NO_TEAM
+}
+
+func (m *Player) GetState() PlayerState {
+	if m != nil {
+		return m.State
+	}
+	return PlayerState_ALIVE
+}
+
 type Laser struct {
 	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Direction            Direction            `protobuf:"varint,2,opt,name=direction,proto3,enum=proto.Direction" json:"direction,omitempty"`
 	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=startTime,proto3" json:"startTime,omitempty"`
 	InitialPosition      *Coordinate          `protobuf:"bytes,4,opt,name=initialPosition,proto3" json:"initialPosition,omitempty"`
 	OwnerId              string               `protobuf:"bytes,5,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Weapon               Weapon               `protobuf:"varint,6,opt,name=weapon,proto3,enum=proto.Weapon" json:"weapon,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -184,7 +387,7 @@ func (m *Laser) Reset()         { *m = Laser{} }
 func (m *Laser) String() string { return proto.CompactTextString(m) }
 func (*Laser) ProtoMessage()    {}
 func (*Laser) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{2}
+	return fileDescriptor_098391ad7281b52b, []int{3}
 }
 
 func (m *Laser) XXX_Unmarshal(b []byte) error {
@@ -240,394 +443,2127 @@ func (m *Laser) GetOwnerId() string {
 	return ""
 }
 
-type Entity struct {
-	// Types that are valid to be assigned to Entity:
-	//	*Entity_Player
-	//	*Entity_Laser
-	Entity               isEntity_Entity `protobuf_oneof:"entity"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *Laser) GetWeapon() Weapon {
+	if m != nil {
+		return m.Weapon
+	}
+	return Weapon_LASER
 }
 
-func (m *Entity) Reset()         { *m = Entity{} }
-func (m *Entity) String() string { return proto.CompactTextString(m) }
-func (*Entity) ProtoMessage()    {}
-func (*Entity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{3}
+type Pickup struct {
+	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind                 PickupKind  `protobuf:"varint,2,opt,name=kind,proto3,enum=proto.PickupKind" json:"kind,omitempty"`
+	Position             *Coordinate `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *Entity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Entity.Unmarshal(m, b)
-}
-func (m *Entity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Entity.Marshal(b, m, deterministic)
-}
-func (m *Entity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Entity.Merge(m, src)
+func (m *Pickup) Reset()         { *m = Pickup{} }
+func (m *Pickup) String() string { return proto.CompactTextString(m) }
+func (*Pickup) ProtoMessage()    {}
+func (*Pickup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{4}
 }
-func (m *Entity) XXX_Size() int {
-	return xxx_messageInfo_Entity.Size(m)
+
+func (m *Pickup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pickup.Unmarshal(m, b)
 }
-func (m *Entity) XXX_DiscardUnknown() {
-	xxx_messageInfo_Entity.DiscardUnknown(m)
+func (m *Pickup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pickup.Marshal(b, m, deterministic)
 }
-
-var xxx_messageInfo_Entity proto.InternalMessageInfo
-
-type isEntity_Entity interface {
-	isEntity_Entity()
+func (m *Pickup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pickup.Merge(m, src)
 }
-
-type Entity_Player struct {
-	Player *Player `protobuf:"bytes,2,opt,name=player,proto3,oneof"`
+func (m *Pickup) XXX_Size() int {
+	return xxx_messageInfo_Pickup.Size(m)
 }
-
-type Entity_Laser struct {
-	Laser *Laser `protobuf:"bytes,3,opt,name=laser,proto3,oneof"`
+func (m *Pickup) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pickup.DiscardUnknown(m)
 }
 
-func (*Entity_Player) isEntity_Entity() {}
-
-func (*Entity_Laser) isEntity_Entity() {}
+var xxx_messageInfo_Pickup proto.InternalMessageInfo
 
-func (m *Entity) GetEntity() isEntity_Entity {
+func (m *Pickup) GetId() string {
 	if m != nil {
-		return m.Entity
+		return m.Id
 	}
-	return nil
+	return ""
 }
 
-func (m *Entity) GetPlayer() *Player {
-	if x, ok := m.GetEntity().(*Entity_Player); ok {
-		return x.Player
+func (m *Pickup) GetKind() PickupKind {
+	if m != nil {
+		return m.Kind
 	}
-	return nil
+	return PickupKind_HEALTH
 }
 
-func (m *Entity) GetLaser() *Laser {
-	if x, ok := m.GetEntity().(*Entity_Laser); ok {
-		return x.Laser
+func (m *Pickup) GetPosition() *Coordinate {
+	if m != nil {
+		return m.Position
 	}
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*Entity) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*Entity_Player)(nil),
-		(*Entity_Laser)(nil),
-	}
-}
-
-type ConnectRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Password             string   `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Flag struct {
+	Id                   string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Team                 Team        `protobuf:"varint,2,opt,name=team,proto3,enum=proto.Team" json:"team,omitempty"`
+	Position             *Coordinate `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	Base                 *Coordinate `protobuf:"bytes,4,opt,name=base,proto3" json:"base,omitempty"`
+	CarrierId            string      `protobuf:"bytes,5,opt,name=carrierId,proto3" json:"carrierId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
-func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
-func (*ConnectRequest) ProtoMessage()    {}
-func (*ConnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{4}
+func (m *Flag) Reset()         { *m = Flag{} }
+func (m *Flag) String() string { return proto.CompactTextString(m) }
+func (*Flag) ProtoMessage()    {}
+func (*Flag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{5}
 }
 
-func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ConnectRequest.Unmarshal(m, b)
+func (m *Flag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Flag.Unmarshal(m, b)
 }
-func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
+func (m *Flag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Flag.Marshal(b, m, deterministic)
 }
-func (m *ConnectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectRequest.Merge(m, src)
+func (m *Flag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Flag.Merge(m, src)
 }
-func (m *ConnectRequest) XXX_Size() int {
-	return xxx_messageInfo_ConnectRequest.Size(m)
+func (m *Flag) XXX_Size() int {
+	return xxx_messageInfo_Flag.Size(m)
 }
-func (m *ConnectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
+func (m *Flag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Flag.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
+var xxx_messageInfo_Flag proto.InternalMessageInfo
 
-func (m *ConnectRequest) GetId() string {
+func (m *Flag) GetId() string {
 	if m != nil {
 		return m.Id
 	}
 	return ""
 }
 
-func (m *ConnectRequest) GetName() string {
+func (m *Flag) GetTeam() Team {
 	if m != nil {
-		return m.Name
+		return m.Team
 	}
-	return ""
+	return Team_NO_TEAM
 }
 
-func (m *ConnectRequest) GetPassword() string {
+func (m *Flag) GetPosition() *Coordinate {
 	if m != nil {
-		return m.Password
+		return m.Position
+	}
+	return nil
+}
+
+func (m *Flag) GetBase() *Coordinate {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *Flag) GetCarrierId() string {
+	if m != nil {
+		return m.CarrierId
 	}
 	return ""
 }
 
-type ConnectResponse struct {
-	Token                string    `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Entities             []*Entity `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type Rectangle struct {
+	Position             *Coordinate `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	Width                int32       `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32       `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-func (m *ConnectResponse) Reset()         { *m = ConnectResponse{} }
-func (m *ConnectResponse) String() string { return proto.CompactTextString(m) }
-func (*ConnectResponse) ProtoMessage()    {}
-func (*ConnectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{5}
+func (m *Rectangle) Reset()         { *m = Rectangle{} }
+func (m *Rectangle) String() string { return proto.CompactTextString(m) }
+func (*Rectangle) ProtoMessage()    {}
+func (*Rectangle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{6}
 }
 
-func (m *ConnectResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ConnectResponse.Unmarshal(m, b)
+func (m *Rectangle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rectangle.Unmarshal(m, b)
 }
-func (m *ConnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ConnectResponse.Marshal(b, m, deterministic)
+func (m *Rectangle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rectangle.Marshal(b, m, deterministic)
 }
-func (m *ConnectResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectResponse.Merge(m, src)
+func (m *Rectangle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rectangle.Merge(m, src)
 }
-func (m *ConnectResponse) XXX_Size() int {
-	return xxx_messageInfo_ConnectResponse.Size(m)
+func (m *Rectangle) XXX_Size() int {
+	return xxx_messageInfo_Rectangle.Size(m)
 }
-func (m *ConnectResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectResponse.DiscardUnknown(m)
+func (m *Rectangle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rectangle.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectResponse proto.InternalMessageInfo
+var xxx_messageInfo_Rectangle proto.InternalMessageInfo
 
-func (m *ConnectResponse) GetToken() string {
+func (m *Rectangle) GetPosition() *Coordinate {
 	if m != nil {
-		return m.Token
+		return m.Position
 	}
-	return ""
+	return nil
 }
 
-func (m *ConnectResponse) GetEntities() []*Entity {
+func (m *Rectangle) GetWidth() int32 {
 	if m != nil {
-		return m.Entities
+		return m.Width
 	}
-	return nil
+	return 0
 }
 
-type Move struct {
-	Direction            Direction `protobuf:"varint,1,opt,name=direction,proto3,enum=proto.Direction" json:"direction,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *Rectangle) GetHeight() int32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type Hill struct {
+	Area                 *Rectangle           `protobuf:"bytes,1,opt,name=area,proto3" json:"area,omitempty"`
+	MovesAt              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=movesAt,proto3" json:"movesAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Hill) Reset()         { *m = Hill{} }
+func (m *Hill) String() string { return proto.CompactTextString(m) }
+func (*Hill) ProtoMessage()    {}
+func (*Hill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{7}
+}
+
+func (m *Hill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Hill.Unmarshal(m, b)
+}
+func (m *Hill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Hill.Marshal(b, m, deterministic)
+}
+func (m *Hill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Hill.Merge(m, src)
+}
+func (m *Hill) XXX_Size() int {
+	return xxx_messageInfo_Hill.Size(m)
+}
+func (m *Hill) XXX_DiscardUnknown() {
+	xxx_messageInfo_Hill.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Hill proto.InternalMessageInfo
+
+func (m *Hill) GetArea() *Rectangle {
+	if m != nil {
+		return m.Area
+	}
+	return nil
+}
+
+func (m *Hill) GetMovesAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.MovesAt
+	}
+	return nil
+}
+
+type Map struct {
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Author               string       `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	MinPlayers           int32        `protobuf:"varint,3,opt,name=minPlayers,proto3" json:"minPlayers,omitempty"`
+	MaxPlayers           int32        `protobuf:"varint,4,opt,name=maxPlayers,proto3" json:"maxPlayers,omitempty"`
+	Rows                 []string     `protobuf:"bytes,5,rep,name=rows,proto3" json:"rows,omitempty"`
+	Hills                []*Rectangle `protobuf:"bytes,6,rep,name=hills,proto3" json:"hills,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *Map) Reset()         { *m = Map{} }
+func (m *Map) String() string { return proto.CompactTextString(m) }
+func (*Map) ProtoMessage()    {}
+func (*Map) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{8}
+}
+
+func (m *Map) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Map.Unmarshal(m, b)
+}
+func (m *Map) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Map.Marshal(b, m, deterministic)
+}
+func (m *Map) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Map.Merge(m, src)
+}
+func (m *Map) XXX_Size() int {
+	return xxx_messageInfo_Map.Size(m)
+}
+func (m *Map) XXX_DiscardUnknown() {
+	xxx_messageInfo_Map.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Map proto.InternalMessageInfo
+
+func (m *Map) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Map) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Map) GetMinPlayers() int32 {
+	if m != nil {
+		return m.MinPlayers
+	}
+	return 0
+}
+
+func (m *Map) GetMaxPlayers() int32 {
+	if m != nil {
+		return m.MaxPlayers
+	}
+	return 0
+}
+
+func (m *Map) GetRows() []string {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+func (m *Map) GetHills() []*Rectangle {
+	if m != nil {
+		return m.Hills
+	}
+	return nil
+}
+
+type Entity struct {
+	// Types that are valid to be assigned to Entity:
+	//	*Entity_Player
+	//	*Entity_Laser
+	//	*Entity_Pickup
+	//	*Entity_Flag
+	Entity               isEntity_Entity `protobuf_oneof:"entity"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Entity) Reset()         { *m = Entity{} }
+func (m *Entity) String() string { return proto.CompactTextString(m) }
+func (*Entity) ProtoMessage()    {}
+func (*Entity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{9}
+}
+
+func (m *Entity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Entity.Unmarshal(m, b)
+}
+func (m *Entity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Entity.Marshal(b, m, deterministic)
+}
+func (m *Entity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Entity.Merge(m, src)
+}
+func (m *Entity) XXX_Size() int {
+	return xxx_messageInfo_Entity.Size(m)
+}
+func (m *Entity) XXX_DiscardUnknown() {
+	xxx_messageInfo_Entity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Entity proto.InternalMessageInfo
+
+type isEntity_Entity interface {
+	isEntity_Entity()
+}
+
+type Entity_Player struct {
+	Player *Player `protobuf:"bytes,2,opt,name=player,proto3,oneof"`
+}
+
+type Entity_Laser struct {
+	Laser *Laser `protobuf:"bytes,3,opt,name=laser,proto3,oneof"`
+}
+
+type Entity_Pickup struct {
+	Pickup *Pickup `protobuf:"bytes,4,opt,name=pickup,proto3,oneof"`
+}
+
+type Entity_Flag struct {
+	Flag *Flag `protobuf:"bytes,5,opt,name=flag,proto3,oneof"`
+}
+
+func (*Entity_Player) isEntity_Entity() {}
+
+func (*Entity_Laser) isEntity_Entity() {}
+
+func (*Entity_Pickup) isEntity_Entity() {}
+
+func (*Entity_Flag) isEntity_Entity() {}
+
+func (m *Entity) GetEntity() isEntity_Entity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+func (m *Entity) GetPlayer() *Player {
+	if x, ok := m.GetEntity().(*Entity_Player); ok {
+		return x.Player
+	}
+	return nil
+}
+
+func (m *Entity) GetLaser() *Laser {
+	if x, ok := m.GetEntity().(*Entity_Laser); ok {
+		return x.Laser
+	}
+	return nil
+}
+
+func (m *Entity) GetPickup() *Pickup {
+	if x, ok := m.GetEntity().(*Entity_Pickup); ok {
+		return x.Pickup
+	}
+	return nil
+}
+
+func (m *Entity) GetFlag() *Flag {
+	if x, ok := m.GetEntity().(*Entity_Flag); ok {
+		return x.Flag
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Entity) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Entity_Player)(nil),
+		(*Entity_Laser)(nil),
+		(*Entity_Pickup)(nil),
+		(*Entity_Flag)(nil),
+	}
+}
+
+type ConnectRequest struct {
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Password  string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Team      Team   `protobuf:"varint,4,opt,name=team,proto3,enum=proto.Team" json:"team,omitempty"`
+	Spectator bool   `protobuf:"varint,5,opt,name=spectator,proto3" json:"spectator,omitempty"`
+	// The name of the room to join, or empty for the default room.
+	Room                 string   `protobuf:"bytes,6,opt,name=room,proto3" json:"room,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectRequest) Reset()         { *m = ConnectRequest{} }
+func (m *ConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnectRequest) ProtoMessage()    {}
+func (*ConnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{10}
+}
+
+func (m *ConnectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectRequest.Unmarshal(m, b)
+}
+func (m *ConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectRequest.Marshal(b, m, deterministic)
+}
+func (m *ConnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectRequest.Merge(m, src)
+}
+func (m *ConnectRequest) XXX_Size() int {
+	return xxx_messageInfo_ConnectRequest.Size(m)
+}
+func (m *ConnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectRequest proto.InternalMessageInfo
+
+func (m *ConnectRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ConnectRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ConnectRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *ConnectRequest) GetTeam() Team {
+	if m != nil {
+		return m.Team
+	}
+	return Team_NO_TEAM
+}
+
+func (m *ConnectRequest) GetSpectator() bool {
+	if m != nil {
+		return m.Spectator
+	}
+	return false
+}
+
+func (m *ConnectRequest) GetRoom() string {
+	if m != nil {
+		return m.Room
+	}
+	return ""
+}
+
+type ConnectResponse struct {
+	Token    string           `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Entities []*Entity        `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	Map      *Map             `protobuf:"bytes,3,opt,name=map,proto3" json:"map,omitempty"`
+	Hill     *Hill            `protobuf:"bytes,4,opt,name=hill,proto3" json:"hill,omitempty"`
+	Scores   map[string]int32 `protobuf:"bytes,5,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Only set if the round is over.
+	RoundOver *RoundOver `protobuf:"bytes,6,opt,name=roundOver,proto3" json:"roundOver,omitempty"`
+	Tick      uint64     `protobuf:"varint,7,opt,name=tick,proto3" json:"tick,omitempty"`
+	// Only set if the game has not started yet.
+	Lobby                *LobbyState `protobuf:"bytes,8,opt,name=lobby,proto3" json:"lobby,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ConnectResponse) Reset()         { *m = ConnectResponse{} }
+func (m *ConnectResponse) String() string { return proto.CompactTextString(m) }
+func (*ConnectResponse) ProtoMessage()    {}
+func (*ConnectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{11}
+}
+
+func (m *ConnectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectResponse.Unmarshal(m, b)
+}
+func (m *ConnectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectResponse.Marshal(b, m, deterministic)
+}
+func (m *ConnectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectResponse.Merge(m, src)
+}
+func (m *ConnectResponse) XXX_Size() int {
+	return xxx_messageInfo_ConnectResponse.Size(m)
+}
+func (m *ConnectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectResponse proto.InternalMessageInfo
+
+func (m *ConnectResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ConnectResponse) GetEntities() []*Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *ConnectResponse) GetMap() *Map {
+	if m != nil {
+		return m.Map
+	}
+	return nil
+}
+
+func (m *ConnectResponse) GetHill() *Hill {
+	if m != nil {
+		return m.Hill
+	}
+	return nil
+}
+
+func (m *ConnectResponse) GetScores() map[string]int32 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+func (m *ConnectResponse) GetRoundOver() *RoundOver {
+	if m != nil {
+		return m.RoundOver
+	}
+	return nil
+}
+
+func (m *ConnectResponse) GetTick() uint64 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+func (m *ConnectResponse) GetLobby() *LobbyState {
+	if m != nil {
+		return m.Lobby
+	}
+	return nil
+}
+
+type InputAck struct {
+	// The last request sequence that the server processed.
+	Sequence uint64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The position of the player after the request was processed.
+	Position             *Coordinate `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *InputAck) Reset()         { *m = InputAck{} }
+func (m *InputAck) String() string { return proto.CompactTextString(m) }
+func (*InputAck) ProtoMessage()    {}
+func (*InputAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{12}
+}
+
+func (m *InputAck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InputAck.Unmarshal(m, b)
+}
+func (m *InputAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InputAck.Marshal(b, m, deterministic)
+}
+func (m *InputAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InputAck.Merge(m, src)
+}
+func (m *InputAck) XXX_Size() int {
+	return xxx_messageInfo_InputAck.Size(m)
+}
+func (m *InputAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_InputAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InputAck proto.InternalMessageInfo
+
+func (m *InputAck) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *InputAck) GetPosition() *Coordinate {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+type Snapshot struct {
+	Tick     uint64           `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Entities []*Entity        `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	Scores   map[string]int32 `protobuf:"bytes,3,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Only set if the round is over.
+	RoundOver *RoundOver `protobuf:"bytes,4,opt,name=roundOver,proto3" json:"roundOver,omitempty"`
+	MapId     string     `protobuf:"bytes,5,opt,name=mapId,proto3" json:"mapId,omitempty"`
+	// Only set when the snapshot was requested with Resync, as maps are large.
+	Map                  *Map     `protobuf:"bytes,6,opt,name=map,proto3" json:"map,omitempty"`
+	Hill                 *Hill    `protobuf:"bytes,7,opt,name=hill,proto3" json:"hill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Snapshot) Reset()         { *m = Snapshot{} }
+func (m *Snapshot) String() string { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()    {}
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{13}
+}
+
+func (m *Snapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Snapshot.Unmarshal(m, b)
+}
+func (m *Snapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Snapshot.Marshal(b, m, deterministic)
+}
+func (m *Snapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Snapshot.Merge(m, src)
+}
+func (m *Snapshot) XXX_Size() int {
+	return xxx_messageInfo_Snapshot.Size(m)
+}
+func (m *Snapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Snapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Snapshot proto.InternalMessageInfo
+
+func (m *Snapshot) GetTick() uint64 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+func (m *Snapshot) GetEntities() []*Entity {
+	if m != nil {
+		return m.Entities
+	}
+	return nil
+}
+
+func (m *Snapshot) GetScores() map[string]int32 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
+}
+
+func (m *Snapshot) GetRoundOver() *RoundOver {
+	if m != nil {
+		return m.RoundOver
+	}
+	return nil
+}
+
+func (m *Snapshot) GetMapId() string {
+	if m != nil {
+		return m.MapId
+	}
+	return ""
+}
+
+func (m *Snapshot) GetMap() *Map {
+	if m != nil {
+		return m.Map
+	}
+	return nil
+}
+
+func (m *Snapshot) GetHill() *Hill {
+	if m != nil {
+		return m.Hill
+	}
+	return nil
+}
+
+type ResumeRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeRequest) Reset()         { *m = ResumeRequest{} }
+func (m *ResumeRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeRequest) ProtoMessage()    {}
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{14}
+}
+
+func (m *ResumeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeRequest.Unmarshal(m, b)
+}
+func (m *ResumeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeRequest.Marshal(b, m, deterministic)
+}
+func (m *ResumeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeRequest.Merge(m, src)
+}
+func (m *ResumeRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeRequest.Size(m)
+}
+func (m *ResumeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeRequest proto.InternalMessageInfo
+
+func (m *ResumeRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type LeaveRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaveRequest) Reset()         { *m = LeaveRequest{} }
+func (m *LeaveRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaveRequest) ProtoMessage()    {}
+func (*LeaveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{15}
+}
+
+func (m *LeaveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaveRequest.Unmarshal(m, b)
+}
+func (m *LeaveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaveRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveRequest.Merge(m, src)
+}
+func (m *LeaveRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaveRequest.Size(m)
+}
+func (m *LeaveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveRequest proto.InternalMessageInfo
+
+func (m *LeaveRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type LeaveResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaveResponse) Reset()         { *m = LeaveResponse{} }
+func (m *LeaveResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaveResponse) ProtoMessage()    {}
+func (*LeaveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{16}
+}
+
+func (m *LeaveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaveResponse.Unmarshal(m, b)
+}
+func (m *LeaveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaveResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveResponse.Merge(m, src)
+}
+func (m *LeaveResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaveResponse.Size(m)
+}
+func (m *LeaveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveResponse proto.InternalMessageInfo
+
+type Room struct {
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mode        string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Map         string `protobuf:"bytes,3,opt,name=map,proto3" json:"map,omitempty"`
+	Players     int32  `protobuf:"varint,4,opt,name=players,proto3" json:"players,omitempty"`
+	MaxPlayers  int32  `protobuf:"varint,5,opt,name=maxPlayers,proto3" json:"maxPlayers,omitempty"`
+	Spectators  int32  `protobuf:"varint,6,opt,name=spectators,proto3" json:"spectators,omitempty"`
+	HasPassword bool   `protobuf:"varint,7,opt,name=hasPassword,proto3" json:"hasPassword,omitempty"`
+	// If players are waiting in the lobby for the match to start.
+	InLobby              bool     `protobuf:"varint,8,opt,name=inLobby,proto3" json:"inLobby,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Room) Reset()         { *m = Room{} }
+func (m *Room) String() string { return proto.CompactTextString(m) }
+func (*Room) ProtoMessage()    {}
+func (*Room) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{17}
+}
+
+func (m *Room) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Room.Unmarshal(m, b)
+}
+func (m *Room) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Room.Marshal(b, m, deterministic)
+}
+func (m *Room) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Room.Merge(m, src)
+}
+func (m *Room) XXX_Size() int {
+	return xxx_messageInfo_Room.Size(m)
+}
+func (m *Room) XXX_DiscardUnknown() {
+	xxx_messageInfo_Room.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Room proto.InternalMessageInfo
+
+func (m *Room) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Room) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *Room) GetMap() string {
+	if m != nil {
+		return m.Map
+	}
+	return ""
+}
+
+func (m *Room) GetPlayers() int32 {
+	if m != nil {
+		return m.Players
+	}
+	return 0
+}
+
+func (m *Room) GetMaxPlayers() int32 {
+	if m != nil {
+		return m.MaxPlayers
+	}
+	return 0
+}
+
+func (m *Room) GetSpectators() int32 {
+	if m != nil {
+		return m.Spectators
+	}
+	return 0
+}
+
+func (m *Room) GetHasPassword() bool {
+	if m != nil {
+		return m.HasPassword
+	}
+	return false
+}
+
+func (m *Room) GetInLobby() bool {
+	if m != nil {
+		return m.InLobby
+	}
+	return false
+}
+
+type CreateRoomRequest struct {
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// One of the server's game modes, or empty for deathmatch.
+	Mode string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// The name of one of the server's maps, or empty to rotate through all
+	// of them.
+	Map  string `protobuf:"bytes,4,opt,name=map,proto3" json:"map,omitempty"`
+	Bots int32  `protobuf:"varint,5,opt,name=bots,proto3" json:"bots,omitempty"`
+	// Makes players wait in a lobby until the match starts.
+	Lobby                bool     `protobuf:"varint,6,opt,name=lobby,proto3" json:"lobby,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRoomRequest) Reset()         { *m = CreateRoomRequest{} }
+func (m *CreateRoomRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRoomRequest) ProtoMessage()    {}
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{18}
+}
+
+func (m *CreateRoomRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRoomRequest.Unmarshal(m, b)
+}
+func (m *CreateRoomRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRoomRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRoomRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRoomRequest.Merge(m, src)
+}
+func (m *CreateRoomRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRoomRequest.Size(m)
+}
+func (m *CreateRoomRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRoomRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRoomRequest proto.InternalMessageInfo
+
+func (m *CreateRoomRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateRoomRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *CreateRoomRequest) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *CreateRoomRequest) GetMap() string {
+	if m != nil {
+		return m.Map
+	}
+	return ""
+}
+
+func (m *CreateRoomRequest) GetBots() int32 {
+	if m != nil {
+		return m.Bots
+	}
+	return 0
+}
+
+func (m *CreateRoomRequest) GetLobby() bool {
+	if m != nil {
+		return m.Lobby
+	}
+	return false
+}
+
+type ListRoomsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRoomsRequest) Reset()         { *m = ListRoomsRequest{} }
+func (m *ListRoomsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRoomsRequest) ProtoMessage()    {}
+func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{19}
+}
+
+func (m *ListRoomsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoomsRequest.Unmarshal(m, b)
+}
+func (m *ListRoomsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoomsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRoomsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoomsRequest.Merge(m, src)
+}
+func (m *ListRoomsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRoomsRequest.Size(m)
+}
+func (m *ListRoomsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoomsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRoomsRequest proto.InternalMessageInfo
+
+type ListRoomsResponse struct {
+	Rooms                []*Room  `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRoomsResponse) Reset()         { *m = ListRoomsResponse{} }
+func (m *ListRoomsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRoomsResponse) ProtoMessage()    {}
+func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{20}
+}
+
+func (m *ListRoomsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRoomsResponse.Unmarshal(m, b)
+}
+func (m *ListRoomsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRoomsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRoomsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRoomsResponse.Merge(m, src)
+}
+func (m *ListRoomsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRoomsResponse.Size(m)
+}
+func (m *ListRoomsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRoomsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRoomsResponse proto.InternalMessageInfo
+
+func (m *ListRoomsResponse) GetRooms() []*Room {
+	if m != nil {
+		return m.Rooms
+	}
+	return nil
+}
+
+type MatchmakeRequest struct {
+	// The name the player will connect with, which their rating is tracked
+	// by.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// One of the server's game modes, or empty for deathmatch.
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// The number of players in the match, including bots. The server's
+	// default is used if zero.
+	Size                 int32    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatchmakeRequest) Reset()         { *m = MatchmakeRequest{} }
+func (m *MatchmakeRequest) String() string { return proto.CompactTextString(m) }
+func (*MatchmakeRequest) ProtoMessage()    {}
+func (*MatchmakeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{21}
+}
+
+func (m *MatchmakeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchmakeRequest.Unmarshal(m, b)
+}
+func (m *MatchmakeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchmakeRequest.Marshal(b, m, deterministic)
+}
+func (m *MatchmakeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchmakeRequest.Merge(m, src)
+}
+func (m *MatchmakeRequest) XXX_Size() int {
+	return xxx_messageInfo_MatchmakeRequest.Size(m)
+}
+func (m *MatchmakeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchmakeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchmakeRequest proto.InternalMessageInfo
+
+func (m *MatchmakeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MatchmakeRequest) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *MatchmakeRequest) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+// Describes the room that a match was created in, which the player should
+// connect to with the given password and team.
+type MatchmakeResponse struct {
+	Room                 string   `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Team                 Team     `protobuf:"varint,3,opt,name=team,proto3,enum=proto.Team" json:"team,omitempty"`
+	Rating               int32    `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Players              int32    `protobuf:"varint,5,opt,name=players,proto3" json:"players,omitempty"`
+	Bots                 int32    `protobuf:"varint,6,opt,name=bots,proto3" json:"bots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatchmakeResponse) Reset()         { *m = MatchmakeResponse{} }
+func (m *MatchmakeResponse) String() string { return proto.CompactTextString(m) }
+func (*MatchmakeResponse) ProtoMessage()    {}
+func (*MatchmakeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{22}
+}
+
+func (m *MatchmakeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchmakeResponse.Unmarshal(m, b)
+}
+func (m *MatchmakeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchmakeResponse.Marshal(b, m, deterministic)
+}
+func (m *MatchmakeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchmakeResponse.Merge(m, src)
+}
+func (m *MatchmakeResponse) XXX_Size() int {
+	return xxx_messageInfo_MatchmakeResponse.Size(m)
+}
+func (m *MatchmakeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchmakeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchmakeResponse proto.InternalMessageInfo
+
+func (m *MatchmakeResponse) GetRoom() string {
+	if m != nil {
+		return m.Room
+	}
+	return ""
+}
+
+func (m *MatchmakeResponse) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+func (m *MatchmakeResponse) GetTeam() Team {
+	if m != nil {
+		return m.Team
+	}
+	return Team_NO_TEAM
+}
+
+func (m *MatchmakeResponse) GetRating() int32 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *MatchmakeResponse) GetPlayers() int32 {
+	if m != nil {
+		return m.Players
+	}
+	return 0
+}
+
+func (m *MatchmakeResponse) GetBots() int32 {
+	if m != nil {
+		return m.Bots
+	}
+	return 0
+}
+
+type LeaderboardRequest struct {
+	// The number of players to return. The server's default is used if zero.
+	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaderboardRequest) Reset()         { *m = LeaderboardRequest{} }
+func (m *LeaderboardRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaderboardRequest) ProtoMessage()    {}
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{23}
+}
+
+func (m *LeaderboardRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderboardRequest.Unmarshal(m, b)
+}
+func (m *LeaderboardRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderboardRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaderboardRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderboardRequest.Merge(m, src)
+}
+func (m *LeaderboardRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaderboardRequest.Size(m)
+}
+func (m *LeaderboardRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderboardRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderboardRequest proto.InternalMessageInfo
+
+func (m *LeaderboardRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type LeaderboardEntry struct {
+	Rank                 int32    `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Rating               int32    `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Rounds               int32    `protobuf:"varint,4,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	Wins                 int32    `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaderboardEntry) Reset()         { *m = LeaderboardEntry{} }
+func (m *LeaderboardEntry) String() string { return proto.CompactTextString(m) }
+func (*LeaderboardEntry) ProtoMessage()    {}
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{24}
+}
+
+func (m *LeaderboardEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderboardEntry.Unmarshal(m, b)
+}
+func (m *LeaderboardEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderboardEntry.Marshal(b, m, deterministic)
+}
+func (m *LeaderboardEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderboardEntry.Merge(m, src)
+}
+func (m *LeaderboardEntry) XXX_Size() int {
+	return xxx_messageInfo_LeaderboardEntry.Size(m)
+}
+func (m *LeaderboardEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderboardEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderboardEntry proto.InternalMessageInfo
+
+func (m *LeaderboardEntry) GetRank() int32 {
+	if m != nil {
+		return m.Rank
+	}
+	return 0
+}
+
+func (m *LeaderboardEntry) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LeaderboardEntry) GetRating() int32 {
+	if m != nil {
+		return m.Rating
+	}
+	return 0
+}
+
+func (m *LeaderboardEntry) GetRounds() int32 {
+	if m != nil {
+		return m.Rounds
+	}
+	return 0
+}
+
+func (m *LeaderboardEntry) GetWins() int32 {
+	if m != nil {
+		return m.Wins
+	}
+	return 0
+}
+
+type LeaderboardResponse struct {
+	Entries              []*LeaderboardEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *LeaderboardResponse) Reset()         { *m = LeaderboardResponse{} }
+func (m *LeaderboardResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaderboardResponse) ProtoMessage()    {}
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{25}
+}
+
+func (m *LeaderboardResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaderboardResponse.Unmarshal(m, b)
+}
+func (m *LeaderboardResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaderboardResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaderboardResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderboardResponse.Merge(m, src)
+}
+func (m *LeaderboardResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaderboardResponse.Size(m)
+}
+func (m *LeaderboardResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderboardResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderboardResponse proto.InternalMessageInfo
+
+func (m *LeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type Move struct {
+	Direction            Direction `protobuf:"varint,1,opt,name=direction,proto3,enum=proto.Direction" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Move) Reset()         { *m = Move{} }
+func (m *Move) String() string { return proto.CompactTextString(m) }
+func (*Move) ProtoMessage()    {}
+func (*Move) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{26}
+}
+
+func (m *Move) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Move.Unmarshal(m, b)
+}
+func (m *Move) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Move.Marshal(b, m, deterministic)
+}
+func (m *Move) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Move.Merge(m, src)
+}
+func (m *Move) XXX_Size() int {
+	return xxx_messageInfo_Move.Size(m)
+}
+func (m *Move) XXX_DiscardUnknown() {
+	xxx_messageInfo_Move.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Move proto.InternalMessageInfo
+
+func (m *Move) GetDirection() Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return Direction_UP
+}
+
+type SwitchWeapon struct {
+	Weapon               Weapon   `protobuf:"varint,1,opt,name=weapon,proto3,enum=proto.Weapon" json:"weapon,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SwitchWeapon) Reset()         { *m = SwitchWeapon{} }
+func (m *SwitchWeapon) String() string { return proto.CompactTextString(m) }
+func (*SwitchWeapon) ProtoMessage()    {}
+func (*SwitchWeapon) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{27}
+}
+
+func (m *SwitchWeapon) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SwitchWeapon.Unmarshal(m, b)
+}
+func (m *SwitchWeapon) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SwitchWeapon.Marshal(b, m, deterministic)
+}
+func (m *SwitchWeapon) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwitchWeapon.Merge(m, src)
+}
+func (m *SwitchWeapon) XXX_Size() int {
+	return xxx_messageInfo_SwitchWeapon.Size(m)
+}
+func (m *SwitchWeapon) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwitchWeapon.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwitchWeapon proto.InternalMessageInfo
+
+func (m *SwitchWeapon) GetWeapon() Weapon {
+	if m != nil {
+		return m.Weapon
+	}
+	return Weapon_LASER
+}
+
+type Resync struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Resync) Reset()         { *m = Resync{} }
+func (m *Resync) String() string { return proto.CompactTextString(m) }
+func (*Resync) ProtoMessage()    {}
+func (*Resync) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{28}
+}
+
+func (m *Resync) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resync.Unmarshal(m, b)
+}
+func (m *Resync) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resync.Marshal(b, m, deterministic)
+}
+func (m *Resync) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resync.Merge(m, src)
+}
+func (m *Resync) XXX_Size() int {
+	return xxx_messageInfo_Resync.Size(m)
+}
+func (m *Resync) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resync.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resync proto.InternalMessageInfo
+
+type AddEntity struct {
+	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddEntity) Reset()         { *m = AddEntity{} }
+func (m *AddEntity) String() string { return proto.CompactTextString(m) }
+func (*AddEntity) ProtoMessage()    {}
+func (*AddEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{29}
+}
+
+func (m *AddEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddEntity.Unmarshal(m, b)
+}
+func (m *AddEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddEntity.Marshal(b, m, deterministic)
+}
+func (m *AddEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddEntity.Merge(m, src)
+}
+func (m *AddEntity) XXX_Size() int {
+	return xxx_messageInfo_AddEntity.Size(m)
+}
+func (m *AddEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddEntity proto.InternalMessageInfo
+
+func (m *AddEntity) GetEntity() *Entity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
+}
+
+type UpdateEntity struct {
+	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateEntity) Reset()         { *m = UpdateEntity{} }
+func (m *UpdateEntity) String() string { return proto.CompactTextString(m) }
+func (*UpdateEntity) ProtoMessage()    {}
+func (*UpdateEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{30}
+}
+
+func (m *UpdateEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateEntity.Unmarshal(m, b)
+}
+func (m *UpdateEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateEntity.Marshal(b, m, deterministic)
+}
+func (m *UpdateEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEntity.Merge(m, src)
+}
+func (m *UpdateEntity) XXX_Size() int {
+	return xxx_messageInfo_UpdateEntity.Size(m)
+}
+func (m *UpdateEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateEntity proto.InternalMessageInfo
+
+func (m *UpdateEntity) GetEntity() *Entity {
+	if m != nil {
+		return m.Entity
+	}
+	return nil
 }
 
-func (m *Move) Reset()         { *m = Move{} }
-func (m *Move) String() string { return proto.CompactTextString(m) }
-func (*Move) ProtoMessage()    {}
-func (*Move) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{6}
+type RemoveEntity struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Move) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Move.Unmarshal(m, b)
+func (m *RemoveEntity) Reset()         { *m = RemoveEntity{} }
+func (m *RemoveEntity) String() string { return proto.CompactTextString(m) }
+func (*RemoveEntity) ProtoMessage()    {}
+func (*RemoveEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{31}
 }
-func (m *Move) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Move.Marshal(b, m, deterministic)
+
+func (m *RemoveEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveEntity.Unmarshal(m, b)
 }
-func (m *Move) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Move.Merge(m, src)
+func (m *RemoveEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveEntity.Marshal(b, m, deterministic)
 }
-func (m *Move) XXX_Size() int {
-	return xxx_messageInfo_Move.Size(m)
+func (m *RemoveEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveEntity.Merge(m, src)
 }
-func (m *Move) XXX_DiscardUnknown() {
-	xxx_messageInfo_Move.DiscardUnknown(m)
+func (m *RemoveEntity) XXX_Size() int {
+	return xxx_messageInfo_RemoveEntity.Size(m)
+}
+func (m *RemoveEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveEntity.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Move proto.InternalMessageInfo
+var xxx_messageInfo_RemoveEntity proto.InternalMessageInfo
 
-func (m *Move) GetDirection() Direction {
+func (m *RemoveEntity) GetId() string {
 	if m != nil {
-		return m.Direction
+		return m.Id
 	}
-	return Direction_UP
+	return ""
 }
 
-type AddEntity struct {
-	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+type PlayerRespawn struct {
+	Player               *Player  `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	KilledById           string   `protobuf:"bytes,2,opt,name=killedById,proto3" json:"killedById,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddEntity) Reset()         { *m = AddEntity{} }
-func (m *AddEntity) String() string { return proto.CompactTextString(m) }
-func (*AddEntity) ProtoMessage()    {}
-func (*AddEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{7}
+func (m *PlayerRespawn) Reset()         { *m = PlayerRespawn{} }
+func (m *PlayerRespawn) String() string { return proto.CompactTextString(m) }
+func (*PlayerRespawn) ProtoMessage()    {}
+func (*PlayerRespawn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{32}
 }
 
-func (m *AddEntity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddEntity.Unmarshal(m, b)
+func (m *PlayerRespawn) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerRespawn.Unmarshal(m, b)
 }
-func (m *AddEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddEntity.Marshal(b, m, deterministic)
+func (m *PlayerRespawn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerRespawn.Marshal(b, m, deterministic)
 }
-func (m *AddEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddEntity.Merge(m, src)
+func (m *PlayerRespawn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerRespawn.Merge(m, src)
 }
-func (m *AddEntity) XXX_Size() int {
-	return xxx_messageInfo_AddEntity.Size(m)
+func (m *PlayerRespawn) XXX_Size() int {
+	return xxx_messageInfo_PlayerRespawn.Size(m)
+}
+func (m *PlayerRespawn) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerRespawn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerRespawn proto.InternalMessageInfo
+
+func (m *PlayerRespawn) GetPlayer() *Player {
+	if m != nil {
+		return m.Player
+	}
+	return nil
+}
+
+func (m *PlayerRespawn) GetKilledById() string {
+	if m != nil {
+		return m.KilledById
+	}
+	return ""
+}
+
+type PlayerDamage struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Health               int32    `protobuf:"varint,2,opt,name=health,proto3" json:"health,omitempty"`
+	DamagedById          string   `protobuf:"bytes,3,opt,name=damagedById,proto3" json:"damagedById,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerDamage) Reset()         { *m = PlayerDamage{} }
+func (m *PlayerDamage) String() string { return proto.CompactTextString(m) }
+func (*PlayerDamage) ProtoMessage()    {}
+func (*PlayerDamage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{33}
+}
+
+func (m *PlayerDamage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerDamage.Unmarshal(m, b)
+}
+func (m *PlayerDamage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerDamage.Marshal(b, m, deterministic)
+}
+func (m *PlayerDamage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerDamage.Merge(m, src)
+}
+func (m *PlayerDamage) XXX_Size() int {
+	return xxx_messageInfo_PlayerDamage.Size(m)
+}
+func (m *PlayerDamage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerDamage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerDamage proto.InternalMessageInfo
+
+func (m *PlayerDamage) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *PlayerDamage) GetHealth() int32 {
+	if m != nil {
+		return m.Health
+	}
+	return 0
+}
+
+func (m *PlayerDamage) GetDamagedById() string {
+	if m != nil {
+		return m.DamagedById
+	}
+	return ""
+}
+
+type CollectPickup struct {
+	Player               *Player  `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	PickupId             string   `protobuf:"bytes,2,opt,name=pickupId,proto3" json:"pickupId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectPickup) Reset()         { *m = CollectPickup{} }
+func (m *CollectPickup) String() string { return proto.CompactTextString(m) }
+func (*CollectPickup) ProtoMessage()    {}
+func (*CollectPickup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{34}
+}
+
+func (m *CollectPickup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectPickup.Unmarshal(m, b)
+}
+func (m *CollectPickup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectPickup.Marshal(b, m, deterministic)
+}
+func (m *CollectPickup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectPickup.Merge(m, src)
+}
+func (m *CollectPickup) XXX_Size() int {
+	return xxx_messageInfo_CollectPickup.Size(m)
+}
+func (m *CollectPickup) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectPickup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectPickup proto.InternalMessageInfo
+
+func (m *CollectPickup) GetPlayer() *Player {
+	if m != nil {
+		return m.Player
+	}
+	return nil
+}
+
+func (m *CollectPickup) GetPickupId() string {
+	if m != nil {
+		return m.PickupId
+	}
+	return ""
+}
+
+type PlayerDeath struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	KilledById           string   `protobuf:"bytes,2,opt,name=killedById,proto3" json:"killedById,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerDeath) Reset()         { *m = PlayerDeath{} }
+func (m *PlayerDeath) String() string { return proto.CompactTextString(m) }
+func (*PlayerDeath) ProtoMessage()    {}
+func (*PlayerDeath) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{35}
+}
+
+func (m *PlayerDeath) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerDeath.Unmarshal(m, b)
+}
+func (m *PlayerDeath) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerDeath.Marshal(b, m, deterministic)
+}
+func (m *PlayerDeath) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerDeath.Merge(m, src)
+}
+func (m *PlayerDeath) XXX_Size() int {
+	return xxx_messageInfo_PlayerDeath.Size(m)
+}
+func (m *PlayerDeath) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerDeath.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerDeath proto.InternalMessageInfo
+
+func (m *PlayerDeath) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *PlayerDeath) GetKilledById() string {
+	if m != nil {
+		return m.KilledById
+	}
+	return ""
+}
+
+type UpdateScore struct {
+	PlayerId             string   `protobuf:"bytes,1,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	Score                int32    `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateScore) Reset()         { *m = UpdateScore{} }
+func (m *UpdateScore) String() string { return proto.CompactTextString(m) }
+func (*UpdateScore) ProtoMessage()    {}
+func (*UpdateScore) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{36}
+}
+
+func (m *UpdateScore) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateScore.Unmarshal(m, b)
+}
+func (m *UpdateScore) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateScore.Marshal(b, m, deterministic)
+}
+func (m *UpdateScore) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateScore.Merge(m, src)
+}
+func (m *UpdateScore) XXX_Size() int {
+	return xxx_messageInfo_UpdateScore.Size(m)
+}
+func (m *UpdateScore) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateScore.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateScore proto.InternalMessageInfo
+
+func (m *UpdateScore) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+func (m *UpdateScore) GetScore() int32 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type PickupFlag struct {
+	FlagId               string   `protobuf:"bytes,1,opt,name=flagId,proto3" json:"flagId,omitempty"`
+	PlayerId             string   `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PickupFlag) Reset()         { *m = PickupFlag{} }
+func (m *PickupFlag) String() string { return proto.CompactTextString(m) }
+func (*PickupFlag) ProtoMessage()    {}
+func (*PickupFlag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{37}
+}
+
+func (m *PickupFlag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PickupFlag.Unmarshal(m, b)
+}
+func (m *PickupFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PickupFlag.Marshal(b, m, deterministic)
+}
+func (m *PickupFlag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PickupFlag.Merge(m, src)
+}
+func (m *PickupFlag) XXX_Size() int {
+	return xxx_messageInfo_PickupFlag.Size(m)
+}
+func (m *PickupFlag) XXX_DiscardUnknown() {
+	xxx_messageInfo_PickupFlag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PickupFlag proto.InternalMessageInfo
+
+func (m *PickupFlag) GetFlagId() string {
+	if m != nil {
+		return m.FlagId
+	}
+	return ""
+}
+
+func (m *PickupFlag) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+type DropFlag struct {
+	Flag                 *Flag    `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropFlag) Reset()         { *m = DropFlag{} }
+func (m *DropFlag) String() string { return proto.CompactTextString(m) }
+func (*DropFlag) ProtoMessage()    {}
+func (*DropFlag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{38}
+}
+
+func (m *DropFlag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropFlag.Unmarshal(m, b)
+}
+func (m *DropFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropFlag.Marshal(b, m, deterministic)
+}
+func (m *DropFlag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropFlag.Merge(m, src)
+}
+func (m *DropFlag) XXX_Size() int {
+	return xxx_messageInfo_DropFlag.Size(m)
+}
+func (m *DropFlag) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropFlag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropFlag proto.InternalMessageInfo
+
+func (m *DropFlag) GetFlag() *Flag {
+	if m != nil {
+		return m.Flag
+	}
+	return nil
+}
+
+type ReturnFlag struct {
+	Flag                 *Flag    `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	PlayerId             string   `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReturnFlag) Reset()         { *m = ReturnFlag{} }
+func (m *ReturnFlag) String() string { return proto.CompactTextString(m) }
+func (*ReturnFlag) ProtoMessage()    {}
+func (*ReturnFlag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{39}
+}
+
+func (m *ReturnFlag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReturnFlag.Unmarshal(m, b)
+}
+func (m *ReturnFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReturnFlag.Marshal(b, m, deterministic)
+}
+func (m *ReturnFlag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReturnFlag.Merge(m, src)
+}
+func (m *ReturnFlag) XXX_Size() int {
+	return xxx_messageInfo_ReturnFlag.Size(m)
 }
-func (m *AddEntity) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddEntity.DiscardUnknown(m)
+func (m *ReturnFlag) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReturnFlag.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddEntity proto.InternalMessageInfo
+var xxx_messageInfo_ReturnFlag proto.InternalMessageInfo
 
-func (m *AddEntity) GetEntity() *Entity {
+func (m *ReturnFlag) GetFlag() *Flag {
 	if m != nil {
-		return m.Entity
+		return m.Flag
 	}
 	return nil
 }
 
-type UpdateEntity struct {
-	Entity               *Entity  `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+func (m *ReturnFlag) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+type CaptureFlag struct {
+	Flag                 *Flag    `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	PlayerId             string   `protobuf:"bytes,2,opt,name=playerId,proto3" json:"playerId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UpdateEntity) Reset()         { *m = UpdateEntity{} }
-func (m *UpdateEntity) String() string { return proto.CompactTextString(m) }
-func (*UpdateEntity) ProtoMessage()    {}
-func (*UpdateEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{8}
+func (m *CaptureFlag) Reset()         { *m = CaptureFlag{} }
+func (m *CaptureFlag) String() string { return proto.CompactTextString(m) }
+func (*CaptureFlag) ProtoMessage()    {}
+func (*CaptureFlag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{40}
 }
 
-func (m *UpdateEntity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UpdateEntity.Unmarshal(m, b)
+func (m *CaptureFlag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CaptureFlag.Unmarshal(m, b)
 }
-func (m *UpdateEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UpdateEntity.Marshal(b, m, deterministic)
+func (m *CaptureFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CaptureFlag.Marshal(b, m, deterministic)
 }
-func (m *UpdateEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UpdateEntity.Merge(m, src)
+func (m *CaptureFlag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureFlag.Merge(m, src)
 }
-func (m *UpdateEntity) XXX_Size() int {
-	return xxx_messageInfo_UpdateEntity.Size(m)
+func (m *CaptureFlag) XXX_Size() int {
+	return xxx_messageInfo_CaptureFlag.Size(m)
 }
-func (m *UpdateEntity) XXX_DiscardUnknown() {
-	xxx_messageInfo_UpdateEntity.DiscardUnknown(m)
+func (m *CaptureFlag) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureFlag.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UpdateEntity proto.InternalMessageInfo
+var xxx_messageInfo_CaptureFlag proto.InternalMessageInfo
 
-func (m *UpdateEntity) GetEntity() *Entity {
+func (m *CaptureFlag) GetFlag() *Flag {
 	if m != nil {
-		return m.Entity
+		return m.Flag
 	}
 	return nil
 }
 
-type RemoveEntity struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (m *CaptureFlag) GetPlayerId() string {
+	if m != nil {
+		return m.PlayerId
+	}
+	return ""
+}
+
+type MoveHill struct {
+	Hill                 *Hill    `protobuf:"bytes,1,opt,name=hill,proto3" json:"hill,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RemoveEntity) Reset()         { *m = RemoveEntity{} }
-func (m *RemoveEntity) String() string { return proto.CompactTextString(m) }
-func (*RemoveEntity) ProtoMessage()    {}
-func (*RemoveEntity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{9}
+func (m *MoveHill) Reset()         { *m = MoveHill{} }
+func (m *MoveHill) String() string { return proto.CompactTextString(m) }
+func (*MoveHill) ProtoMessage()    {}
+func (*MoveHill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{41}
 }
 
-func (m *RemoveEntity) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RemoveEntity.Unmarshal(m, b)
+func (m *MoveHill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveHill.Unmarshal(m, b)
 }
-func (m *RemoveEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RemoveEntity.Marshal(b, m, deterministic)
+func (m *MoveHill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveHill.Marshal(b, m, deterministic)
 }
-func (m *RemoveEntity) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RemoveEntity.Merge(m, src)
+func (m *MoveHill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveHill.Merge(m, src)
 }
-func (m *RemoveEntity) XXX_Size() int {
-	return xxx_messageInfo_RemoveEntity.Size(m)
+func (m *MoveHill) XXX_Size() int {
+	return xxx_messageInfo_MoveHill.Size(m)
 }
-func (m *RemoveEntity) XXX_DiscardUnknown() {
-	xxx_messageInfo_RemoveEntity.DiscardUnknown(m)
+func (m *MoveHill) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveHill.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RemoveEntity proto.InternalMessageInfo
+var xxx_messageInfo_MoveHill proto.InternalMessageInfo
 
-func (m *RemoveEntity) GetId() string {
+func (m *MoveHill) GetHill() *Hill {
 	if m != nil {
-		return m.Id
+		return m.Hill
 	}
-	return ""
+	return nil
 }
 
-type PlayerRespawn struct {
-	Player               *Player  `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
-	KilledById           string   `protobuf:"bytes,2,opt,name=killedById,proto3" json:"killedById,omitempty"`
+// Placement is a group of players who share a place in a round.
+type Placement struct {
+	PlayerIds            []string `protobuf:"bytes,1,rep,name=playerIds,proto3" json:"playerIds,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PlayerRespawn) Reset()         { *m = PlayerRespawn{} }
-func (m *PlayerRespawn) String() string { return proto.CompactTextString(m) }
-func (*PlayerRespawn) ProtoMessage()    {}
-func (*PlayerRespawn) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{10}
+func (m *Placement) Reset()         { *m = Placement{} }
+func (m *Placement) String() string { return proto.CompactTextString(m) }
+func (*Placement) ProtoMessage()    {}
+func (*Placement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{42}
 }
 
-func (m *PlayerRespawn) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PlayerRespawn.Unmarshal(m, b)
+func (m *Placement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Placement.Unmarshal(m, b)
 }
-func (m *PlayerRespawn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PlayerRespawn.Marshal(b, m, deterministic)
+func (m *Placement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Placement.Marshal(b, m, deterministic)
 }
-func (m *PlayerRespawn) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PlayerRespawn.Merge(m, src)
+func (m *Placement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Placement.Merge(m, src)
 }
-func (m *PlayerRespawn) XXX_Size() int {
-	return xxx_messageInfo_PlayerRespawn.Size(m)
+func (m *Placement) XXX_Size() int {
+	return xxx_messageInfo_Placement.Size(m)
 }
-func (m *PlayerRespawn) XXX_DiscardUnknown() {
-	xxx_messageInfo_PlayerRespawn.DiscardUnknown(m)
+func (m *Placement) XXX_DiscardUnknown() {
+	xxx_messageInfo_Placement.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PlayerRespawn proto.InternalMessageInfo
+var xxx_messageInfo_Placement proto.InternalMessageInfo
 
-func (m *PlayerRespawn) GetPlayer() *Player {
+func (m *Placement) GetPlayerIds() []string {
 	if m != nil {
-		return m.Player
+		return m.PlayerIds
 	}
 	return nil
 }
 
-func (m *PlayerRespawn) GetKilledById() string {
-	if m != nil {
-		return m.KilledById
-	}
-	return ""
-}
-
 type RoundOver struct {
 	RoundWinnerId        string               `protobuf:"bytes,1,opt,name=roundWinnerId,proto3" json:"roundWinnerId,omitempty"`
 	NewRoundAt           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=newRoundAt,proto3" json:"newRoundAt,omitempty"`
+	RoundWinnerTeam      Team                 `protobuf:"varint,3,opt,name=roundWinnerTeam,proto3,enum=proto.Team" json:"roundWinnerTeam,omitempty"`
+	Placements           []*Placement         `protobuf:"bytes,4,rep,name=placements,proto3" json:"placements,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -637,7 +2573,7 @@ func (m *RoundOver) Reset()         { *m = RoundOver{} }
 func (m *RoundOver) String() string { return proto.CompactTextString(m) }
 func (*RoundOver) ProtoMessage()    {}
 func (*RoundOver) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{11}
+	return fileDescriptor_098391ad7281b52b, []int{43}
 }
 
 func (m *RoundOver) XXX_Unmarshal(b []byte) error {
@@ -672,6 +2608,20 @@ func (m *RoundOver) GetNewRoundAt() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *RoundOver) GetRoundWinnerTeam() Team {
+	if m != nil {
+		return m.RoundWinnerTeam
+	}
+	return Team_NO_TEAM
+}
+
+func (m *RoundOver) GetPlacements() []*Placement {
+	if m != nil {
+		return m.Placements
+	}
+	return nil
+}
+
 type RoundStart struct {
 	Players              []*Player `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -683,7 +2633,7 @@ func (m *RoundStart) Reset()         { *m = RoundStart{} }
 func (m *RoundStart) String() string { return proto.CompactTextString(m) }
 func (*RoundStart) ProtoMessage()    {}
 func (*RoundStart) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{12}
+	return fileDescriptor_098391ad7281b52b, []int{44}
 }
 
 func (m *RoundStart) XXX_Unmarshal(b []byte) error {
@@ -711,10 +2661,292 @@ func (m *RoundStart) GetPlayers() []*Player {
 	return nil
 }
 
+type LobbyMember struct {
+	// The ID the player will have once the match starts.
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Icon  string `protobuf:"bytes,3,opt,name=icon,proto3" json:"icon,omitempty"`
+	Team  Team   `protobuf:"varint,4,opt,name=team,proto3,enum=proto.Team" json:"team,omitempty"`
+	Ready bool   `protobuf:"varint,5,opt,name=ready,proto3" json:"ready,omitempty"`
+	// The host can start the match before everyone is ready.
+	Host                 bool     `protobuf:"varint,6,opt,name=host,proto3" json:"host,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LobbyMember) Reset()         { *m = LobbyMember{} }
+func (m *LobbyMember) String() string { return proto.CompactTextString(m) }
+func (*LobbyMember) ProtoMessage()    {}
+func (*LobbyMember) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{45}
+}
+
+func (m *LobbyMember) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LobbyMember.Unmarshal(m, b)
+}
+func (m *LobbyMember) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LobbyMember.Marshal(b, m, deterministic)
+}
+func (m *LobbyMember) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LobbyMember.Merge(m, src)
+}
+func (m *LobbyMember) XXX_Size() int {
+	return xxx_messageInfo_LobbyMember.Size(m)
+}
+func (m *LobbyMember) XXX_DiscardUnknown() {
+	xxx_messageInfo_LobbyMember.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LobbyMember proto.InternalMessageInfo
+
+func (m *LobbyMember) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *LobbyMember) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LobbyMember) GetIcon() string {
+	if m != nil {
+		return m.Icon
+	}
+	return ""
+}
+
+func (m *LobbyMember) GetTeam() Team {
+	if m != nil {
+		return m.Team
+	}
+	return Team_NO_TEAM
+}
+
+func (m *LobbyMember) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *LobbyMember) GetHost() bool {
+	if m != nil {
+		return m.Host
+	}
+	return false
+}
+
+type LobbyState struct {
+	Members []*LobbyMember `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	// If the game mode has teams, so members can choose one.
+	Teams bool   `protobuf:"varint,2,opt,name=teams,proto3" json:"teams,omitempty"`
+	Mode  string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Set once the match has started, at which point clients leave the
+	// lobby.
+	Started              bool     `protobuf:"varint,4,opt,name=started,proto3" json:"started,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LobbyState) Reset()         { *m = LobbyState{} }
+func (m *LobbyState) String() string { return proto.CompactTextString(m) }
+func (*LobbyState) ProtoMessage()    {}
+func (*LobbyState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{46}
+}
+
+func (m *LobbyState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LobbyState.Unmarshal(m, b)
+}
+func (m *LobbyState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LobbyState.Marshal(b, m, deterministic)
+}
+func (m *LobbyState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LobbyState.Merge(m, src)
+}
+func (m *LobbyState) XXX_Size() int {
+	return xxx_messageInfo_LobbyState.Size(m)
+}
+func (m *LobbyState) XXX_DiscardUnknown() {
+	xxx_messageInfo_LobbyState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LobbyState proto.InternalMessageInfo
+
+func (m *LobbyState) GetMembers() []*LobbyMember {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+func (m *LobbyState) GetTeams() bool {
+	if m != nil {
+		return m.Teams
+	}
+	return false
+}
+
+func (m *LobbyState) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *LobbyState) GetStarted() bool {
+	if m != nil {
+		return m.Started
+	}
+	return false
+}
+
+type UpdateLobby struct {
+	Team Team `protobuf:"varint,1,opt,name=team,proto3,enum=proto.Team" json:"team,omitempty"`
+	// A single character, or empty to keep the current icon.
+	Icon                 string   `protobuf:"bytes,2,opt,name=icon,proto3" json:"icon,omitempty"`
+	Ready                bool     `protobuf:"varint,3,opt,name=ready,proto3" json:"ready,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateLobby) Reset()         { *m = UpdateLobby{} }
+func (m *UpdateLobby) String() string { return proto.CompactTextString(m) }
+func (*UpdateLobby) ProtoMessage()    {}
+func (*UpdateLobby) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{47}
+}
+
+func (m *UpdateLobby) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateLobby.Unmarshal(m, b)
+}
+func (m *UpdateLobby) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateLobby.Marshal(b, m, deterministic)
+}
+func (m *UpdateLobby) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateLobby.Merge(m, src)
+}
+func (m *UpdateLobby) XXX_Size() int {
+	return xxx_messageInfo_UpdateLobby.Size(m)
+}
+func (m *UpdateLobby) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateLobby.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateLobby proto.InternalMessageInfo
+
+func (m *UpdateLobby) GetTeam() Team {
+	if m != nil {
+		return m.Team
+	}
+	return Team_NO_TEAM
+}
+
+func (m *UpdateLobby) GetIcon() string {
+	if m != nil {
+		return m.Icon
+	}
+	return ""
+}
+
+func (m *UpdateLobby) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+type StartMatch struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartMatch) Reset()         { *m = StartMatch{} }
+func (m *StartMatch) String() string { return proto.CompactTextString(m) }
+func (*StartMatch) ProtoMessage()    {}
+func (*StartMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{48}
+}
+
+func (m *StartMatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartMatch.Unmarshal(m, b)
+}
+func (m *StartMatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartMatch.Marshal(b, m, deterministic)
+}
+func (m *StartMatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartMatch.Merge(m, src)
+}
+func (m *StartMatch) XXX_Size() int {
+	return xxx_messageInfo_StartMatch.Size(m)
+}
+func (m *StartMatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartMatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartMatch proto.InternalMessageInfo
+
+type ChangeMap struct {
+	Map                  *Map     `protobuf:"bytes,1,opt,name=map,proto3" json:"map,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChangeMap) Reset()         { *m = ChangeMap{} }
+func (m *ChangeMap) String() string { return proto.CompactTextString(m) }
+func (*ChangeMap) ProtoMessage()    {}
+func (*ChangeMap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_098391ad7281b52b, []int{49}
+}
+
+func (m *ChangeMap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChangeMap.Unmarshal(m, b)
+}
+func (m *ChangeMap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChangeMap.Marshal(b, m, deterministic)
+}
+func (m *ChangeMap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangeMap.Merge(m, src)
+}
+func (m *ChangeMap) XXX_Size() int {
+	return xxx_messageInfo_ChangeMap.Size(m)
+}
+func (m *ChangeMap) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangeMap.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangeMap proto.InternalMessageInfo
+
+func (m *ChangeMap) GetMap() *Map {
+	if m != nil {
+		return m.Map
+	}
+	return nil
+}
+
 type Request struct {
+	// Increases by one for every request sent by a client.
+	Sequence uint64 `protobuf:"varint,100,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The server time of the game state the client was showing when the
+	// request was sent. Used to compensate for latency when firing.
+	ViewTime *timestamp.Timestamp `protobuf:"bytes,101,opt,name=viewTime,proto3" json:"viewTime,omitempty"`
 	// Types that are valid to be assigned to Action:
 	//	*Request_Move
 	//	*Request_Laser
+	//	*Request_SwitchWeapon
+	//	*Request_Resync
+	//	*Request_UpdateLobby
+	//	*Request_StartMatch
 	Action               isRequest_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
@@ -725,7 +2957,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{13}
+	return fileDescriptor_098391ad7281b52b, []int{50}
 }
 
 func (m *Request) XXX_Unmarshal(b []byte) error {
@@ -746,6 +2978,20 @@ func (m *Request) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Request proto.InternalMessageInfo
 
+func (m *Request) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Request) GetViewTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ViewTime
+	}
+	return nil
+}
+
 type isRequest_Action interface {
 	isRequest_Action()
 }
@@ -758,10 +3004,34 @@ type Request_Laser struct {
 	Laser *Laser `protobuf:"bytes,2,opt,name=laser,proto3,oneof"`
 }
 
+type Request_SwitchWeapon struct {
+	SwitchWeapon *SwitchWeapon `protobuf:"bytes,3,opt,name=switchWeapon,proto3,oneof"`
+}
+
+type Request_Resync struct {
+	Resync *Resync `protobuf:"bytes,4,opt,name=resync,proto3,oneof"`
+}
+
+type Request_UpdateLobby struct {
+	UpdateLobby *UpdateLobby `protobuf:"bytes,5,opt,name=updateLobby,proto3,oneof"`
+}
+
+type Request_StartMatch struct {
+	StartMatch *StartMatch `protobuf:"bytes,6,opt,name=startMatch,proto3,oneof"`
+}
+
 func (*Request_Move) isRequest_Action() {}
 
 func (*Request_Laser) isRequest_Action() {}
 
+func (*Request_SwitchWeapon) isRequest_Action() {}
+
+func (*Request_Resync) isRequest_Action() {}
+
+func (*Request_UpdateLobby) isRequest_Action() {}
+
+func (*Request_StartMatch) isRequest_Action() {}
+
 func (m *Request) GetAction() isRequest_Action {
 	if m != nil {
 		return m.Action
@@ -783,15 +3053,53 @@ func (m *Request) GetLaser() *Laser {
 	return nil
 }
 
+func (m *Request) GetSwitchWeapon() *SwitchWeapon {
+	if x, ok := m.GetAction().(*Request_SwitchWeapon); ok {
+		return x.SwitchWeapon
+	}
+	return nil
+}
+
+func (m *Request) GetResync() *Resync {
+	if x, ok := m.GetAction().(*Request_Resync); ok {
+		return x.Resync
+	}
+	return nil
+}
+
+func (m *Request) GetUpdateLobby() *UpdateLobby {
+	if x, ok := m.GetAction().(*Request_UpdateLobby); ok {
+		return x.UpdateLobby
+	}
+	return nil
+}
+
+func (m *Request) GetStartMatch() *StartMatch {
+	if x, ok := m.GetAction().(*Request_StartMatch); ok {
+		return x.StartMatch
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Request) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
 		(*Request_Move)(nil),
 		(*Request_Laser)(nil),
+		(*Request_SwitchWeapon)(nil),
+		(*Request_Resync)(nil),
+		(*Request_UpdateLobby)(nil),
+		(*Request_StartMatch)(nil),
 	}
 }
 
 type Response struct {
+	// Increases by one for every response sent to a client.
+	Sequence uint64 `protobuf:"varint,100,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// The server tick when the response was sent.
+	Tick uint64 `protobuf:"varint,101,opt,name=tick,proto3" json:"tick,omitempty"`
+	// The server time when the response was sent.
+	ServerTime *timestamp.Timestamp `protobuf:"bytes,102,opt,name=serverTime,proto3" json:"serverTime,omitempty"`
 	// Types that are valid to be assigned to Action:
 	//	*Response_AddEntity
 	//	*Response_UpdateEntity
@@ -799,6 +3107,19 @@ type Response struct {
 	//	*Response_PlayerRespawn
 	//	*Response_RoundOver
 	//	*Response_RoundStart
+	//	*Response_ChangeMap
+	//	*Response_PlayerDamage
+	//	*Response_CollectPickup
+	//	*Response_UpdateScore
+	//	*Response_PickupFlag
+	//	*Response_DropFlag
+	//	*Response_ReturnFlag
+	//	*Response_CaptureFlag
+	//	*Response_MoveHill
+	//	*Response_PlayerDeath
+	//	*Response_Snapshot
+	//	*Response_InputAck
+	//	*Response_LobbyState
 	Action               isResponse_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
@@ -809,7 +3130,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_098391ad7281b52b, []int{14}
+	return fileDescriptor_098391ad7281b52b, []int{51}
 }
 
 func (m *Response) XXX_Unmarshal(b []byte) error {
@@ -830,6 +3151,27 @@ func (m *Response) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Response proto.InternalMessageInfo
 
+func (m *Response) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Response) GetTick() uint64 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+func (m *Response) GetServerTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ServerTime
+	}
+	return nil
+}
+
 type isResponse_Action interface {
 	isResponse_Action()
 }
@@ -858,6 +3200,58 @@ type Response_RoundStart struct {
 	RoundStart *RoundStart `protobuf:"bytes,6,opt,name=roundStart,proto3,oneof"`
 }
 
+type Response_ChangeMap struct {
+	ChangeMap *ChangeMap `protobuf:"bytes,7,opt,name=changeMap,proto3,oneof"`
+}
+
+type Response_PlayerDamage struct {
+	PlayerDamage *PlayerDamage `protobuf:"bytes,8,opt,name=playerDamage,proto3,oneof"`
+}
+
+type Response_CollectPickup struct {
+	CollectPickup *CollectPickup `protobuf:"bytes,9,opt,name=collectPickup,proto3,oneof"`
+}
+
+type Response_UpdateScore struct {
+	UpdateScore *UpdateScore `protobuf:"bytes,10,opt,name=updateScore,proto3,oneof"`
+}
+
+type Response_PickupFlag struct {
+	PickupFlag *PickupFlag `protobuf:"bytes,11,opt,name=pickupFlag,proto3,oneof"`
+}
+
+type Response_DropFlag struct {
+	DropFlag *DropFlag `protobuf:"bytes,12,opt,name=dropFlag,proto3,oneof"`
+}
+
+type Response_ReturnFlag struct {
+	ReturnFlag *ReturnFlag `protobuf:"bytes,13,opt,name=returnFlag,proto3,oneof"`
+}
+
+type Response_CaptureFlag struct {
+	CaptureFlag *CaptureFlag `protobuf:"bytes,14,opt,name=captureFlag,proto3,oneof"`
+}
+
+type Response_MoveHill struct {
+	MoveHill *MoveHill `protobuf:"bytes,15,opt,name=moveHill,proto3,oneof"`
+}
+
+type Response_PlayerDeath struct {
+	PlayerDeath *PlayerDeath `protobuf:"bytes,16,opt,name=playerDeath,proto3,oneof"`
+}
+
+type Response_Snapshot struct {
+	Snapshot *Snapshot `protobuf:"bytes,17,opt,name=snapshot,proto3,oneof"`
+}
+
+type Response_InputAck struct {
+	InputAck *InputAck `protobuf:"bytes,18,opt,name=inputAck,proto3,oneof"`
+}
+
+type Response_LobbyState struct {
+	LobbyState *LobbyState `protobuf:"bytes,19,opt,name=lobbyState,proto3,oneof"`
+}
+
 func (*Response_AddEntity) isResponse_Action() {}
 
 func (*Response_UpdateEntity) isResponse_Action() {}
@@ -870,6 +3264,32 @@ func (*Response_RoundOver) isResponse_Action() {}
 
 func (*Response_RoundStart) isResponse_Action() {}
 
+func (*Response_ChangeMap) isResponse_Action() {}
+
+func (*Response_PlayerDamage) isResponse_Action() {}
+
+func (*Response_CollectPickup) isResponse_Action() {}
+
+func (*Response_UpdateScore) isResponse_Action() {}
+
+func (*Response_PickupFlag) isResponse_Action() {}
+
+func (*Response_DropFlag) isResponse_Action() {}
+
+func (*Response_ReturnFlag) isResponse_Action() {}
+
+func (*Response_CaptureFlag) isResponse_Action() {}
+
+func (*Response_MoveHill) isResponse_Action() {}
+
+func (*Response_PlayerDeath) isResponse_Action() {}
+
+func (*Response_Snapshot) isResponse_Action() {}
+
+func (*Response_InputAck) isResponse_Action() {}
+
+func (*Response_LobbyState) isResponse_Action() {}
+
 func (m *Response) GetAction() isResponse_Action {
 	if m != nil {
 		return m.Action
@@ -919,6 +3339,97 @@ func (m *Response) GetRoundStart() *RoundStart {
 	return nil
 }
 
+func (m *Response) GetChangeMap() *ChangeMap {
+	if x, ok := m.GetAction().(*Response_ChangeMap); ok {
+		return x.ChangeMap
+	}
+	return nil
+}
+
+func (m *Response) GetPlayerDamage() *PlayerDamage {
+	if x, ok := m.GetAction().(*Response_PlayerDamage); ok {
+		return x.PlayerDamage
+	}
+	return nil
+}
+
+func (m *Response) GetCollectPickup() *CollectPickup {
+	if x, ok := m.GetAction().(*Response_CollectPickup); ok {
+		return x.CollectPickup
+	}
+	return nil
+}
+
+func (m *Response) GetUpdateScore() *UpdateScore {
+	if x, ok := m.GetAction().(*Response_UpdateScore); ok {
+		return x.UpdateScore
+	}
+	return nil
+}
+
+func (m *Response) GetPickupFlag() *PickupFlag {
+	if x, ok := m.GetAction().(*Response_PickupFlag); ok {
+		return x.PickupFlag
+	}
+	return nil
+}
+
+func (m *Response) GetDropFlag() *DropFlag {
+	if x, ok := m.GetAction().(*Response_DropFlag); ok {
+		return x.DropFlag
+	}
+	return nil
+}
+
+func (m *Response) GetReturnFlag() *ReturnFlag {
+	if x, ok := m.GetAction().(*Response_ReturnFlag); ok {
+		return x.ReturnFlag
+	}
+	return nil
+}
+
+func (m *Response) GetCaptureFlag() *CaptureFlag {
+	if x, ok := m.GetAction().(*Response_CaptureFlag); ok {
+		return x.CaptureFlag
+	}
+	return nil
+}
+
+func (m *Response) GetMoveHill() *MoveHill {
+	if x, ok := m.GetAction().(*Response_MoveHill); ok {
+		return x.MoveHill
+	}
+	return nil
+}
+
+func (m *Response) GetPlayerDeath() *PlayerDeath {
+	if x, ok := m.GetAction().(*Response_PlayerDeath); ok {
+		return x.PlayerDeath
+	}
+	return nil
+}
+
+func (m *Response) GetSnapshot() *Snapshot {
+	if x, ok := m.GetAction().(*Response_Snapshot); ok {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (m *Response) GetInputAck() *InputAck {
+	if x, ok := m.GetAction().(*Response_InputAck); ok {
+		return x.InputAck
+	}
+	return nil
+}
+
+func (m *Response) GetLobbyState() *LobbyState {
+	if x, ok := m.GetAction().(*Response_LobbyState); ok {
+		return x.LobbyState
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Response) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -928,24 +3439,80 @@ func (*Response) XXX_OneofWrappers() []interface{} {
 		(*Response_PlayerRespawn)(nil),
 		(*Response_RoundOver)(nil),
 		(*Response_RoundStart)(nil),
+		(*Response_ChangeMap)(nil),
+		(*Response_PlayerDamage)(nil),
+		(*Response_CollectPickup)(nil),
+		(*Response_UpdateScore)(nil),
+		(*Response_PickupFlag)(nil),
+		(*Response_DropFlag)(nil),
+		(*Response_ReturnFlag)(nil),
+		(*Response_CaptureFlag)(nil),
+		(*Response_MoveHill)(nil),
+		(*Response_PlayerDeath)(nil),
+		(*Response_Snapshot)(nil),
+		(*Response_InputAck)(nil),
+		(*Response_LobbyState)(nil),
 	}
 }
 
 func init() {
 	proto.RegisterEnum("proto.Direction", Direction_name, Direction_value)
+	proto.RegisterEnum("proto.Weapon", Weapon_name, Weapon_value)
+	proto.RegisterEnum("proto.PickupKind", PickupKind_name, PickupKind_value)
+	proto.RegisterEnum("proto.Team", Team_name, Team_value)
+	proto.RegisterEnum("proto.PlayerState", PlayerState_name, PlayerState_value)
 	proto.RegisterType((*Coordinate)(nil), "proto.Coordinate")
+	proto.RegisterType((*Effect)(nil), "proto.Effect")
 	proto.RegisterType((*Player)(nil), "proto.Player")
 	proto.RegisterType((*Laser)(nil), "proto.Laser")
+	proto.RegisterType((*Pickup)(nil), "proto.Pickup")
+	proto.RegisterType((*Flag)(nil), "proto.Flag")
+	proto.RegisterType((*Rectangle)(nil), "proto.Rectangle")
+	proto.RegisterType((*Hill)(nil), "proto.Hill")
+	proto.RegisterType((*Map)(nil), "proto.Map")
 	proto.RegisterType((*Entity)(nil), "proto.Entity")
 	proto.RegisterType((*ConnectRequest)(nil), "proto.ConnectRequest")
 	proto.RegisterType((*ConnectResponse)(nil), "proto.ConnectResponse")
+	proto.RegisterMapType((map[string]int32)(nil), "proto.ConnectResponse.ScoresEntry")
+	proto.RegisterType((*InputAck)(nil), "proto.InputAck")
+	proto.RegisterType((*Snapshot)(nil), "proto.Snapshot")
+	proto.RegisterMapType((map[string]int32)(nil), "proto.Snapshot.ScoresEntry")
+	proto.RegisterType((*ResumeRequest)(nil), "proto.ResumeRequest")
+	proto.RegisterType((*LeaveRequest)(nil), "proto.LeaveRequest")
+	proto.RegisterType((*LeaveResponse)(nil), "proto.LeaveResponse")
+	proto.RegisterType((*Room)(nil), "proto.Room")
+	proto.RegisterType((*CreateRoomRequest)(nil), "proto.CreateRoomRequest")
+	proto.RegisterType((*ListRoomsRequest)(nil), "proto.ListRoomsRequest")
+	proto.RegisterType((*ListRoomsResponse)(nil), "proto.ListRoomsResponse")
+	proto.RegisterType((*MatchmakeRequest)(nil), "proto.MatchmakeRequest")
+	proto.RegisterType((*MatchmakeResponse)(nil), "proto.MatchmakeResponse")
+	proto.RegisterType((*LeaderboardRequest)(nil), "proto.LeaderboardRequest")
+	proto.RegisterType((*LeaderboardEntry)(nil), "proto.LeaderboardEntry")
+	proto.RegisterType((*LeaderboardResponse)(nil), "proto.LeaderboardResponse")
 	proto.RegisterType((*Move)(nil), "proto.Move")
+	proto.RegisterType((*SwitchWeapon)(nil), "proto.SwitchWeapon")
+	proto.RegisterType((*Resync)(nil), "proto.Resync")
 	proto.RegisterType((*AddEntity)(nil), "proto.AddEntity")
 	proto.RegisterType((*UpdateEntity)(nil), "proto.UpdateEntity")
 	proto.RegisterType((*RemoveEntity)(nil), "proto.RemoveEntity")
 	proto.RegisterType((*PlayerRespawn)(nil), "proto.PlayerRespawn")
+	proto.RegisterType((*PlayerDamage)(nil), "proto.PlayerDamage")
+	proto.RegisterType((*CollectPickup)(nil), "proto.CollectPickup")
+	proto.RegisterType((*PlayerDeath)(nil), "proto.PlayerDeath")
+	proto.RegisterType((*UpdateScore)(nil), "proto.UpdateScore")
+	proto.RegisterType((*PickupFlag)(nil), "proto.PickupFlag")
+	proto.RegisterType((*DropFlag)(nil), "proto.DropFlag")
+	proto.RegisterType((*ReturnFlag)(nil), "proto.ReturnFlag")
+	proto.RegisterType((*CaptureFlag)(nil), "proto.CaptureFlag")
+	proto.RegisterType((*MoveHill)(nil), "proto.MoveHill")
+	proto.RegisterType((*Placement)(nil), "proto.Placement")
 	proto.RegisterType((*RoundOver)(nil), "proto.RoundOver")
 	proto.RegisterType((*RoundStart)(nil), "proto.RoundStart")
+	proto.RegisterType((*LobbyMember)(nil), "proto.LobbyMember")
+	proto.RegisterType((*LobbyState)(nil), "proto.LobbyState")
+	proto.RegisterType((*UpdateLobby)(nil), "proto.UpdateLobby")
+	proto.RegisterType((*StartMatch)(nil), "proto.StartMatch")
+	proto.RegisterType((*ChangeMap)(nil), "proto.ChangeMap")
 	proto.RegisterType((*Request)(nil), "proto.Request")
 	proto.RegisterType((*Response)(nil), "proto.Response")
 }
@@ -955,55 +3522,171 @@ func init() {
 }
 
 var fileDescriptor_098391ad7281b52b = []byte{
-	// 768 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x6f, 0xeb, 0x44,
-	0x14, 0xb5, 0x5d, 0xc7, 0x89, 0x6f, 0x93, 0x34, 0x0c, 0x0f, 0x64, 0x65, 0xf1, 0x28, 0x16, 0xe8,
-	0x05, 0x24, 0x92, 0xa7, 0x3c, 0x15, 0x41, 0xe9, 0xa6, 0x5f, 0xd4, 0x95, 0x0a, 0x8d, 0xa6, 0x29,
-	0xdd, 0xb0, 0x71, 0xeb, 0xa1, 0x1a, 0x35, 0x9e, 0x31, 0xf6, 0xa4, 0x69, 0xfe, 0x29, 0x5b, 0xfe,
-	0x09, 0x9a, 0x0f, 0x7f, 0xa4, 0x14, 0x0a, 0x2b, 0xcf, 0x9d, 0x39, 0xf7, 0xde, 0xb9, 0xe7, 0x1c,
-	0x0f, 0x0c, 0xb2, 0x9c, 0x0b, 0x3e, 0x49, 0x63, 0xca, 0xc6, 0x6a, 0x89, 0x5a, 0xea, 0x33, 0xfc,
-	0xec, 0x9e, 0xf3, 0xfb, 0x05, 0x99, 0xa8, 0xe8, 0x76, 0xf9, 0xdb, 0x44, 0xd0, 0x94, 0x14, 0x22,
-	0x4e, 0x33, 0x8d, 0x0b, 0x47, 0x00, 0xc7, 0x9c, 0xe7, 0x09, 0x65, 0xb1, 0x20, 0xa8, 0x0b, 0xf6,
-	0x53, 0x60, 0xef, 0xda, 0xa3, 0x16, 0xb6, 0x9f, 0x64, 0xb4, 0x0e, 0x1c, 0x1d, 0xad, 0x43, 0x0e,
-	0xde, 0x6c, 0x11, 0xaf, 0x49, 0x8e, 0xfa, 0xe0, 0xd0, 0x44, 0xc1, 0x7c, 0xec, 0xd0, 0x04, 0x21,
-	0x70, 0x59, 0x9c, 0x12, 0x05, 0xf5, 0xb1, 0x5a, 0xa3, 0x6f, 0xa0, 0x93, 0xf1, 0x82, 0x0a, 0xca,
-	0x59, 0xb0, 0xb5, 0x6b, 0x8f, 0xb6, 0xa7, 0x1f, 0xe9, 0x8e, 0xe3, 0xba, 0x1d, 0xae, 0x20, 0xb2,
-	0x04, 0xbd, 0xe3, 0x2c, 0x70, 0x75, 0x09, 0xb9, 0x0e, 0xff, 0xb0, 0xa1, 0x75, 0x11, 0x17, 0x2f,
-	0x34, 0x1c, 0x83, 0x9f, 0xd0, 0x9c, 0xdc, 0xa9, 0xea, 0xb2, 0x6b, 0x7f, 0x3a, 0x30, 0xd5, 0x4f,
-	0xca, 0x7d, 0x5c, 0x43, 0xd0, 0x77, 0xe0, 0x17, 0x22, 0xce, 0xc5, 0x9c, 0xa6, 0xc4, 0xdc, 0x66,
-	0x38, 0xd6, 0xcc, 0x8c, 0x4b, 0x66, 0xc6, 0xf3, 0x92, 0x19, 0x5c, 0x83, 0xd1, 0x0f, 0xb0, 0x43,
-	0x19, 0x15, 0x34, 0x5e, 0xcc, 0xca, 0x69, 0xdc, 0x7f, 0x9a, 0xe6, 0x39, 0x12, 0x05, 0xd0, 0xe6,
-	0x2b, 0x46, 0xf2, 0xf3, 0x24, 0x68, 0xa9, 0xbb, 0x97, 0x61, 0x18, 0x83, 0x77, 0xca, 0x04, 0x15,
-	0x6b, 0xf4, 0x0e, 0xbc, 0x4c, 0xb1, 0xaa, 0xe6, 0xd8, 0x9e, 0xf6, 0x4c, 0x5d, 0x4d, 0x75, 0x64,
-	0x61, 0x73, 0x8c, 0xbe, 0x80, 0xd6, 0x42, 0x92, 0x61, 0xee, 0xdf, 0x35, 0x38, 0x45, 0x50, 0x64,
-	0x61, 0x7d, 0x78, 0xd4, 0x01, 0x8f, 0xa8, 0xc2, 0xe1, 0x0c, 0xfa, 0xc7, 0x9c, 0x31, 0x72, 0x27,
-	0x30, 0xf9, 0x7d, 0x49, 0x0a, 0xf1, 0x9f, 0x64, 0x1b, 0x42, 0x27, 0x8b, 0x8b, 0x62, 0xc5, 0xf3,
-	0x44, 0x35, 0xf2, 0x71, 0x15, 0x87, 0x18, 0x76, 0xaa, 0x8a, 0x45, 0xc6, 0x59, 0x41, 0xd0, 0x1b,
-	0x68, 0x09, 0xfe, 0x40, 0x98, 0xa9, 0xaa, 0x03, 0xf4, 0x15, 0x74, 0xd4, 0x25, 0x28, 0x29, 0x02,
-	0x67, 0x77, 0xab, 0x31, 0x95, 0x1e, 0x1a, 0x57, 0xc7, 0xe1, 0xb7, 0xe0, 0xfe, 0xc4, 0x1f, 0xc9,
-	0xa6, 0xa2, 0xf6, 0xab, 0x8a, 0x86, 0x53, 0xf0, 0x0f, 0x93, 0xc4, 0x70, 0xf8, 0x65, 0x39, 0xb4,
-	0xca, 0xfc, 0x5b, 0xb7, 0x92, 0x91, 0x3d, 0xe8, 0x5e, 0x67, 0x49, 0x2c, 0xc8, 0xff, 0x4b, 0x7b,
-	0x0b, 0x5d, 0x4c, 0x52, 0xfe, 0x58, 0xa6, 0x3d, 0xa3, 0x31, 0xfc, 0x05, 0x7a, 0x5a, 0x2c, 0xc9,
-	0x4a, 0xbc, 0x62, 0xb2, 0xae, 0x91, 0xd4, 0x7e, 0x41, 0xd2, 0x4a, 0xd0, 0xb7, 0x00, 0x0f, 0x74,
-	0xb1, 0x20, 0xc9, 0xd1, 0xfa, 0x3c, 0x31, 0x22, 0x34, 0x76, 0xc2, 0x14, 0x7c, 0xcc, 0x97, 0x2c,
-	0xb9, 0x7c, 0x54, 0xea, 0xf7, 0x72, 0x19, 0xdc, 0x50, 0xa6, 0x0d, 0xa5, 0xfb, 0x6f, 0x6e, 0xa2,
-	0x7d, 0x00, 0x46, 0x56, 0x2a, 0xeb, 0x50, 0x18, 0x43, 0xfd, 0x9b, 0xd1, 0x1b, 0xe8, 0x70, 0x0f,
-	0x40, 0x2d, 0xaf, 0xa4, 0xf7, 0xd1, 0x3b, 0x68, 0xeb, 0x6b, 0x16, 0x81, 0xbd, 0xa1, 0xa0, 0x19,
-	0xa2, 0x3c, 0x0d, 0x7f, 0x85, 0x76, 0xe9, 0xaf, 0xcf, 0xc1, 0x95, 0x34, 0x99, 0xa9, 0xb7, 0x4d,
-	0x82, 0x94, 0x37, 0xb2, 0xb0, 0x3a, 0xaa, 0x4d, 0xec, 0xbc, 0x62, 0xe2, 0x58, 0xcb, 0xfc, 0xa7,
-	0x03, 0x9d, 0xca, 0x6c, 0xef, 0xc1, 0x8f, 0x4b, 0xcd, 0x4d, 0x93, 0xd2, 0x23, 0x95, 0x17, 0x22,
-	0x0b, 0xd7, 0x20, 0xf4, 0x3d, 0x74, 0x97, 0x0d, 0xc5, 0x4d, 0xd7, 0x8f, 0x4d, 0x52, 0xd3, 0x0c,
-	0x91, 0x85, 0x37, 0xa0, 0x32, 0x35, 0x6f, 0xa8, 0x6e, 0xfe, 0xba, 0x32, 0xb5, 0x69, 0x08, 0x99,
-	0xda, 0x84, 0xa2, 0x03, 0xe8, 0x65, 0x4d, 0x43, 0x98, 0x17, 0xe3, 0xcd, 0x26, 0x83, 0xfa, 0x2c,
-	0xb2, 0xf0, 0x26, 0x58, 0x4e, 0x99, 0x97, 0xb2, 0xab, 0x67, 0xa3, 0x9e, 0xb2, 0xb2, 0x83, 0x9c,
-	0xb2, 0x02, 0xa1, 0x0f, 0x00, 0x79, 0xa5, 0x5c, 0xe0, 0x6d, 0x3c, 0x4f, 0xb5, 0xa4, 0x91, 0x85,
-	0x1b, 0xb0, 0x9a, 0xe3, 0xaf, 0x0f, 0xc0, 0xaf, 0x7e, 0x31, 0xe4, 0x81, 0x73, 0x3d, 0x1b, 0x58,
-	0xa8, 0x03, 0xee, 0xc9, 0xe5, 0xcd, 0xcf, 0x03, 0x5b, 0xae, 0x2e, 0x4e, 0x7f, 0x9c, 0x0f, 0x1c,
-	0xe4, 0x43, 0x0b, 0x9f, 0x9f, 0x45, 0xf3, 0xc1, 0x96, 0xdc, 0xbc, 0x9a, 0x5f, 0xce, 0x06, 0xee,
-	0xb4, 0x00, 0xf7, 0x4c, 0x3e, 0x1c, 0xfb, 0xd0, 0x36, 0x8f, 0x03, 0xfa, 0xa4, 0x7a, 0x1a, 0x9b,
-	0xcf, 0xcf, 0xf0, 0xd3, 0xe7, 0xdb, 0x5a, 0xd6, 0xd0, 0x42, 0x13, 0xf0, 0xae, 0x44, 0x4e, 0xe2,
-	0x14, 0xf5, 0x2b, 0x7e, 0x75, 0xce, 0x4e, 0x15, 0x97, 0xe0, 0x91, 0xfd, 0xde, 0xbe, 0xf5, 0xd4,
-	0xee, 0x87, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x4f, 0x54, 0x08, 0x41, 0xf7, 0x06, 0x00, 0x00,
+	// 2611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x39, 0xdd, 0x72, 0x1b, 0x49,
+	0xd5, 0x1a, 0x69, 0x34, 0xd2, 0x1c, 0xf9, 0x47, 0xe9, 0xf8, 0xcb, 0x37, 0x98, 0xad, 0xac, 0x77,
+	0x2a, 0xd9, 0x78, 0xbd, 0xe0, 0x84, 0x64, 0x13, 0xb2, 0x61, 0xab, 0x88, 0x63, 0x2b, 0x91, 0x40,
+	0x8e, 0x5d, 0x2d, 0x27, 0x81, 0xab, 0x54, 0x5b, 0xea, 0x58, 0x53, 0xd6, 0xfc, 0x30, 0x33, 0xb2,
+	0x63, 0x2e, 0xb6, 0x8a, 0x37, 0xa0, 0x8a, 0x37, 0x80, 0x3b, 0x28, 0x5e, 0x81, 0xc7, 0xe0, 0x11,
+	0x78, 0x03, 0xae, 0xb8, 0x80, 0xea, 0xdf, 0xe9, 0x91, 0xe5, 0x9f, 0xd4, 0x5e, 0x69, 0x4e, 0x9f,
+	0xd3, 0xa7, 0xcf, 0x7f, 0x9f, 0xd3, 0x82, 0x76, 0x92, 0xc6, 0x79, 0x7c, 0x3f, 0x24, 0x41, 0xb4,
+	0xc9, 0x3f, 0x51, 0x9d, 0xff, 0xac, 0x7e, 0x7e, 0x14, 0xc7, 0x47, 0x13, 0x7a, 0x9f, 0x43, 0x87,
+	0xd3, 0x0f, 0xf7, 0xf3, 0x20, 0xa4, 0x59, 0x4e, 0xc2, 0x44, 0xd0, 0xf9, 0xeb, 0x00, 0xdb, 0x71,
+	0x9c, 0x8e, 0x82, 0x88, 0xe4, 0x14, 0x2d, 0x80, 0xf5, 0xd1, 0xb3, 0xd6, 0xac, 0xf5, 0x3a, 0xb6,
+	0x3e, 0x32, 0xe8, 0xcc, 0xab, 0x0a, 0xe8, 0xcc, 0x0f, 0xc0, 0xe9, 0x7c, 0xf8, 0x40, 0x87, 0x39,
+	0xba, 0x0b, 0xf6, 0x71, 0x10, 0x8d, 0x38, 0xe1, 0xd2, 0xc3, 0x1b, 0x82, 0xd3, 0xe6, 0x7e, 0x30,
+	0x3c, 0x9e, 0x26, 0xbf, 0x0e, 0xa2, 0x11, 0xe6, 0x68, 0xf4, 0x14, 0x5c, 0xfa, 0x31, 0x09, 0x52,
+	0x9a, 0x6d, 0xe5, 0x9c, 0x4d, 0xeb, 0xe1, 0xea, 0xa6, 0x90, 0x67, 0x53, 0xc9, 0xb3, 0x79, 0xa0,
+	0xe4, 0xc1, 0x05, 0xb1, 0xff, 0xe7, 0x2a, 0x38, 0xfb, 0x13, 0x72, 0x46, 0x53, 0xb4, 0x04, 0xd5,
+	0x40, 0x9c, 0xe4, 0xe2, 0x6a, 0x30, 0x42, 0x08, 0xec, 0x88, 0x84, 0x94, 0xf3, 0x73, 0x31, 0xff,
+	0x46, 0x3f, 0x85, 0x66, 0x12, 0x67, 0x41, 0x1e, 0xc4, 0x91, 0x57, 0xe3, 0xe7, 0x28, 0x99, 0x0a,
+	0xd5, 0xb0, 0x26, 0x61, 0x2c, 0x82, 0x61, 0x1c, 0x79, 0xb6, 0x60, 0xc1, 0xbe, 0xd1, 0x2d, 0x70,
+	0xc6, 0x94, 0x4c, 0xf2, 0xb1, 0x57, 0xe7, 0xfa, 0x4a, 0x08, 0xdd, 0x05, 0xe7, 0x94, 0x92, 0x24,
+	0x8e, 0x3c, 0x87, 0x2b, 0xbb, 0x28, 0x19, 0xbf, 0xe3, 0x8b, 0x58, 0x22, 0xd1, 0x3d, 0x68, 0x50,
+	0x6e, 0x9b, 0xcc, 0x6b, 0xac, 0xd5, 0xd6, 0x5b, 0x9a, 0x4e, 0x58, 0x0c, 0x2b, 0x2c, 0xfa, 0x1c,
+	0xec, 0x9c, 0x92, 0xd0, 0x6b, 0x72, 0x6e, 0x2d, 0x49, 0x75, 0x40, 0x49, 0x88, 0x39, 0x02, 0xad,
+	0x43, 0x3d, 0xcb, 0x49, 0x4e, 0x3d, 0x97, 0x53, 0x20, 0x65, 0x5c, 0x6e, 0x8d, 0x01, 0xc3, 0x60,
+	0x41, 0xe0, 0xff, 0xd7, 0x82, 0x7a, 0x9f, 0x64, 0x73, 0x6c, 0xb4, 0x09, 0xee, 0x28, 0x48, 0xe9,
+	0x90, 0x1b, 0xa4, 0xca, 0xf9, 0xb4, 0x25, 0x9f, 0x1d, 0xb5, 0x8e, 0x0b, 0x12, 0xe6, 0xa8, 0x2c,
+	0x27, 0x69, 0xce, 0x7c, 0xe1, 0xd5, 0xae, 0x76, 0x94, 0x26, 0x46, 0xbf, 0x80, 0xe5, 0x20, 0x0a,
+	0xf2, 0x80, 0x4c, 0xf6, 0x95, 0x03, 0xec, 0x8b, 0x1c, 0x30, 0x4b, 0x89, 0x3c, 0x68, 0xc4, 0xa7,
+	0x11, 0x4d, 0x7b, 0x23, 0x6e, 0x74, 0x17, 0x2b, 0xf0, 0x9a, 0x56, 0xf7, 0x23, 0x70, 0x44, 0xd0,
+	0x9d, 0xb3, 0x80, 0x8a, 0xd0, 0xea, 0xe5, 0x11, 0xfa, 0x69, 0x81, 0xe3, 0xff, 0xcd, 0x02, 0xfb,
+	0xe5, 0x84, 0x1c, 0x9d, 0x3b, 0x4e, 0x79, 0xb5, 0x7a, 0x91, 0x57, 0x3f, 0x31, 0x42, 0xef, 0x82,
+	0x7d, 0x48, 0x32, 0x7a, 0xb1, 0x2d, 0x39, 0x1a, 0x7d, 0x06, 0xee, 0x90, 0xa4, 0x69, 0x60, 0x98,
+	0xb0, 0x58, 0xf0, 0xc7, 0xe0, 0x62, 0x3a, 0xcc, 0x49, 0x74, 0x34, 0x29, 0xa7, 0x88, 0x75, 0xb5,
+	0x00, 0x2b, 0x50, 0x3f, 0x0d, 0x46, 0xf9, 0x58, 0x66, 0xbf, 0x00, 0x44, 0x92, 0x04, 0x47, 0xe3,
+	0xdc, 0xab, 0xa9, 0x24, 0x61, 0x90, 0x7f, 0x08, 0x76, 0x37, 0x98, 0x4c, 0xd0, 0x1d, 0xb0, 0x49,
+	0x4a, 0x89, 0x3c, 0x40, 0x85, 0x9c, 0x16, 0x02, 0x73, 0x2c, 0xfa, 0x06, 0x1a, 0x61, 0x7c, 0x72,
+	0xcd, 0xa2, 0xa0, 0x48, 0xfd, 0xbf, 0x5b, 0x50, 0xdb, 0x25, 0x89, 0xce, 0x7f, 0xcb, 0xc8, 0xff,
+	0x5b, 0xe0, 0x90, 0x69, 0x3e, 0x8e, 0x53, 0x59, 0x15, 0x24, 0x84, 0x6e, 0x03, 0x84, 0x41, 0x24,
+	0x52, 0x27, 0x93, 0x32, 0x1b, 0x2b, 0x1c, 0x4f, 0x3e, 0x2a, 0xbc, 0x2d, 0xf1, 0x7a, 0x85, 0x9d,
+	0x95, 0xc6, 0xa7, 0x99, 0x57, 0x5f, 0xab, 0xb1, 0xb3, 0xd8, 0x37, 0xfa, 0x12, 0xea, 0xe3, 0x60,
+	0x32, 0xc9, 0x3c, 0x67, 0xad, 0x36, 0x57, 0x49, 0x81, 0x66, 0xf2, 0x3a, 0x9d, 0x28, 0x0f, 0xf2,
+	0x33, 0x74, 0x0f, 0x9c, 0x84, 0x73, 0x94, 0xfa, 0x2e, 0x96, 0x72, 0xba, 0x5b, 0xc1, 0x12, 0x8d,
+	0xee, 0x40, 0x7d, 0xc2, 0x12, 0x5a, 0x86, 0xc8, 0x82, 0xa4, 0xe3, 0x49, 0xde, 0xad, 0x60, 0x81,
+	0xe4, 0xec, 0x78, 0x20, 0x7b, 0x76, 0x99, 0x1d, 0x5f, 0xe4, 0xec, 0xf8, 0x17, 0xfa, 0x02, 0xec,
+	0x0f, 0x13, 0x72, 0xc4, 0x23, 0xa3, 0xa5, 0xa3, 0x92, 0x05, 0x70, 0xb7, 0x82, 0x39, 0xea, 0x45,
+	0x13, 0x1c, 0xca, 0x85, 0xf4, 0xff, 0x62, 0xc1, 0xd2, 0x76, 0x1c, 0x45, 0xac, 0x5a, 0xd1, 0xdf,
+	0x4d, 0x69, 0x96, 0x5f, 0xab, 0xf4, 0xae, 0x42, 0x33, 0x21, 0x59, 0x76, 0x1a, 0xa7, 0x23, 0x2e,
+	0xb5, 0x8b, 0x35, 0xac, 0xb3, 0xc2, 0xbe, 0x28, 0x2b, 0x3e, 0x03, 0x37, 0x4b, 0x98, 0xe1, 0xf2,
+	0x38, 0xe5, 0x52, 0x36, 0x71, 0xb1, 0x20, 0xac, 0x1f, 0x87, 0xbc, 0x04, 0x70, 0xeb, 0xc7, 0xa1,
+	0xff, 0xef, 0x2a, 0x2c, 0x6b, 0x29, 0xb3, 0x24, 0x8e, 0x32, 0xca, 0x62, 0x35, 0x8f, 0x8f, 0x69,
+	0x24, 0x25, 0x15, 0x00, 0xfa, 0x0a, 0x9a, 0x5c, 0xb3, 0x80, 0x66, 0x5e, 0xb5, 0x5c, 0x92, 0xb9,
+	0xc2, 0x58, 0xa3, 0xd1, 0x67, 0x50, 0x0b, 0x49, 0x22, 0x8d, 0x0e, 0x92, 0x6a, 0x97, 0x24, 0x98,
+	0x2d, 0x33, 0x2d, 0x98, 0x47, 0xa5, 0xb1, 0x95, 0x16, 0x2c, 0xde, 0x31, 0x47, 0xa0, 0x67, 0xe0,
+	0x64, 0xc3, 0x38, 0xa5, 0x22, 0x4e, 0x5a, 0x0f, 0x7d, 0x9d, 0x58, 0x25, 0x39, 0x37, 0x07, 0x9c,
+	0xa8, 0x13, 0xe5, 0xe9, 0x19, 0x96, 0x3b, 0x58, 0xa5, 0x4e, 0xe3, 0x69, 0x34, 0xda, 0x3b, 0xa1,
+	0x29, 0x57, 0xd4, 0x88, 0x28, 0xb5, 0x8e, 0x0b, 0x12, 0x66, 0x93, 0x3c, 0x18, 0x1e, 0x7b, 0x8d,
+	0x35, 0x6b, 0xdd, 0xc6, 0xfc, 0x1b, 0xdd, 0x83, 0xfa, 0x24, 0x3e, 0x3c, 0x3c, 0xf3, 0x9a, 0xa5,
+	0xbc, 0xee, 0xb3, 0x35, 0x79, 0x61, 0x70, 0xfc, 0xea, 0xb7, 0xd0, 0x32, 0x64, 0x40, 0x6d, 0xa8,
+	0x1d, 0xd3, 0x33, 0x69, 0x35, 0xf6, 0xc9, 0x2c, 0x79, 0x42, 0x26, 0x53, 0xaa, 0xb2, 0x9e, 0x03,
+	0xcf, 0xaa, 0x4f, 0x2d, 0xff, 0x0d, 0x34, 0x7b, 0x51, 0x32, 0xcd, 0xb7, 0x86, 0xc7, 0xcc, 0xe5,
+	0x19, 0x8b, 0x90, 0x68, 0x28, 0xb2, 0xd0, 0xc6, 0x1a, 0x2e, 0x95, 0x99, 0xea, 0xd5, 0x05, 0xf5,
+	0x1f, 0x55, 0x68, 0x0e, 0x22, 0x92, 0x64, 0xe3, 0x38, 0xd7, 0xba, 0x59, 0x86, 0x6e, 0x9f, 0xe0,
+	0xc5, 0x47, 0xda, 0x0d, 0x35, 0x4e, 0xf8, 0x63, 0x49, 0xa8, 0xf8, 0x5f, 0x6d, 0x7f, 0xfb, 0x6a,
+	0xfb, 0xaf, 0x40, 0x3d, 0x24, 0x89, 0xae, 0xb6, 0x02, 0x50, 0x01, 0xe4, 0x5c, 0x1e, 0x40, 0x8d,
+	0x0b, 0x02, 0xe8, 0x87, 0xf8, 0xe5, 0x2e, 0x2c, 0x62, 0x9a, 0x4d, 0x43, 0xaa, 0x72, 0x76, 0x6e,
+	0x32, 0xf8, 0x77, 0x60, 0xa1, 0x4f, 0xc9, 0xc9, 0x15, 0x54, 0xcb, 0xb0, 0x28, 0xa9, 0x44, 0xc4,
+	0xfa, 0xff, 0xb4, 0xc0, 0xc6, 0x71, 0x1c, 0xce, 0x2d, 0xba, 0x08, 0xec, 0x30, 0x1e, 0xe9, 0x6a,
+	0xc0, 0xbe, 0x51, 0x5b, 0x18, 0x42, 0x14, 0x02, 0xae, 0xbc, 0x07, 0x8d, 0xa4, 0x54, 0x5f, 0x1b,
+	0xc9, 0xdc, 0xe2, 0x5b, 0x3f, 0x57, 0x7c, 0x6f, 0x03, 0xe8, 0x5a, 0x90, 0x71, 0xdb, 0xd6, 0xb1,
+	0xb1, 0x82, 0xd6, 0xa0, 0x35, 0x26, 0xd9, 0xbe, 0x2a, 0x3e, 0x0d, 0x5e, 0x3e, 0xcc, 0x25, 0x76,
+	0x76, 0x10, 0xf5, 0x75, 0x6a, 0x34, 0xb1, 0x02, 0xfd, 0x3f, 0x59, 0x70, 0x63, 0x3b, 0xa5, 0x2c,
+	0x18, 0xe3, 0x38, 0x54, 0x56, 0x99, 0xa7, 0xa5, 0x59, 0xdf, 0xaa, 0x33, 0xf5, 0x4d, 0x59, 0xa0,
+	0x76, 0xde, 0x02, 0x76, 0x61, 0x01, 0x04, 0xf6, 0x61, 0x9c, 0x2b, 0x0d, 0xf9, 0x37, 0xb3, 0xbf,
+	0x48, 0x59, 0x87, 0xcb, 0x25, 0x00, 0x1f, 0x41, 0xbb, 0x1f, 0x64, 0x39, 0x13, 0x29, 0x93, 0x32,
+	0xf9, 0x4f, 0xe0, 0x86, 0xb1, 0x26, 0x2b, 0xde, 0x17, 0x50, 0x67, 0xd5, 0x30, 0xf3, 0xac, 0xb5,
+	0x9a, 0x11, 0x52, 0x5c, 0x17, 0x81, 0xf1, 0x5f, 0x43, 0x7b, 0x97, 0xe4, 0xc3, 0x71, 0x48, 0x8e,
+	0xe9, 0x65, 0xfa, 0xcd, 0xf3, 0x22, 0x02, 0x3b, 0x0b, 0x7e, 0x4f, 0xe5, 0x85, 0xc9, 0xbf, 0xfd,
+	0xbf, 0x5a, 0x70, 0xc3, 0x60, 0x28, 0x05, 0x51, 0x25, 0xda, 0x2a, 0x4a, 0xf4, 0xa5, 0x16, 0x53,
+	0x37, 0x42, 0xed, 0xa2, 0x1b, 0xe1, 0x16, 0x38, 0x29, 0xc9, 0x83, 0xe8, 0x48, 0x46, 0x8b, 0x84,
+	0xcc, 0x30, 0xaa, 0x97, 0xc3, 0x48, 0x99, 0xd7, 0x29, 0xcc, 0xeb, 0x6f, 0x00, 0xea, 0x53, 0x32,
+	0xa2, 0xe9, 0x61, 0x4c, 0xd2, 0x91, 0x11, 0xf4, 0x93, 0x20, 0x0c, 0x72, 0x39, 0xdf, 0x08, 0xc0,
+	0xff, 0x1e, 0xda, 0x06, 0xad, 0xc8, 0x40, 0xa6, 0x16, 0x89, 0x8e, 0x25, 0x21, 0xff, 0x9e, 0x7b,
+	0xf9, 0x15, 0xd2, 0xd6, 0x4a, 0xd2, 0xb2, 0x75, 0x56, 0x32, 0x32, 0xad, 0x05, 0x87, 0x18, 0x8f,
+	0xd3, 0x20, 0xd2, 0xa1, 0xc0, 0xbe, 0xfd, 0x2e, 0xdc, 0x2c, 0xc9, 0x2a, 0x2d, 0xfb, 0x33, 0x68,
+	0xd0, 0x28, 0x4f, 0x03, 0xaa, 0x9c, 0xfc, 0xff, 0xaa, 0xac, 0xcf, 0x08, 0x8b, 0x15, 0x9d, 0xff,
+	0x04, 0xec, 0xdd, 0xf8, 0x84, 0x96, 0xbb, 0x7f, 0xeb, 0xca, 0xee, 0xdf, 0x7f, 0x0c, 0x0b, 0x83,
+	0xd3, 0x20, 0x1f, 0x8e, 0x45, 0x77, 0x6d, 0x34, 0xdf, 0xd6, 0x65, 0xcd, 0x77, 0x13, 0x1c, 0x4c,
+	0xb3, 0xb3, 0x68, 0xe8, 0x3f, 0x04, 0x77, 0x6b, 0x34, 0x92, 0xcd, 0xce, 0x5d, 0xd5, 0x51, 0xc8,
+	0x2e, 0x70, 0xa6, 0x5e, 0x4b, 0x24, 0x3b, 0xf4, 0x4d, 0x32, 0x22, 0x39, 0xfd, 0xb4, 0x6d, 0xb7,
+	0x61, 0x01, 0x53, 0xd6, 0x12, 0xca, 0x6d, 0x33, 0x2d, 0x8a, 0xff, 0x16, 0x16, 0x45, 0xfd, 0x60,
+	0x86, 0x24, 0xa7, 0x5c, 0x19, 0xd9, 0x7b, 0x59, 0x73, 0x7a, 0x2f, 0xdd, 0x79, 0xdd, 0x06, 0x38,
+	0x0e, 0x26, 0x13, 0x3a, 0x7a, 0x71, 0xd6, 0x53, 0x61, 0x6b, 0xac, 0xf8, 0x23, 0x58, 0x10, 0x3b,
+	0x76, 0x48, 0x48, 0x8e, 0x44, 0x59, 0xe0, 0x70, 0x4f, 0x9d, 0xae, 0x61, 0x63, 0x94, 0xac, 0x96,
+	0x46, 0xc9, 0x35, 0x68, 0x8d, 0xf8, 0x6e, 0x71, 0x88, 0xa8, 0x1a, 0xe6, 0x92, 0x8f, 0x61, 0x71,
+	0x3b, 0x9e, 0x4c, 0xe8, 0x30, 0x97, 0x63, 0xcd, 0x35, 0xa5, 0x67, 0xd2, 0xf0, 0x0d, 0xbd, 0x22,
+	0xe5, 0x24, 0xec, 0xf7, 0xa0, 0x25, 0x25, 0xa7, 0x24, 0x1f, 0x5f, 0x2a, 0xf8, 0x55, 0x46, 0xf8,
+	0x25, 0xb4, 0x84, 0xcf, 0xf8, 0x6d, 0x75, 0x29, 0xab, 0x15, 0xa8, 0xf3, 0x1b, 0x56, 0xdd, 0x58,
+	0x1c, 0xf0, 0x9f, 0x03, 0x08, 0xc5, 0xf8, 0x10, 0x75, 0x0b, 0x1c, 0xd6, 0x83, 0xea, 0xdd, 0x12,
+	0x2a, 0xf1, 0xad, 0x96, 0xf9, 0xfa, 0x5f, 0x43, 0x73, 0x27, 0x8d, 0xc5, 0xfe, 0xcf, 0x65, 0x7b,
+	0x6b, 0x9d, 0x6b, 0x6f, 0x45, 0x73, 0xeb, 0xf7, 0x00, 0x30, 0xcd, 0xa7, 0x69, 0x74, 0x2d, 0xf2,
+	0x4b, 0xcf, 0xfd, 0x15, 0xb4, 0xb6, 0x49, 0x92, 0x4f, 0x53, 0xfa, 0xc3, 0x79, 0x7d, 0x0d, 0x4d,
+	0x96, 0xa7, 0x7c, 0x62, 0x52, 0xbd, 0x81, 0x75, 0x41, 0x6f, 0xe0, 0x7f, 0x05, 0xee, 0xfe, 0x84,
+	0x0c, 0x69, 0x48, 0xa3, 0x9c, 0xf5, 0xcb, 0x8a, 0x8b, 0x28, 0x0b, 0x2e, 0x2e, 0x16, 0xd8, 0x6d,
+	0xed, 0xea, 0xa6, 0x05, 0xdd, 0x81, 0x45, 0x5e, 0x75, 0xde, 0x05, 0x51, 0x64, 0xb8, 0xa8, 0xbc,
+	0x88, 0x9e, 0x01, 0x44, 0xf4, 0x94, 0xef, 0xba, 0xd6, 0x38, 0x66, 0x50, 0xa3, 0xc7, 0xb0, 0x6c,
+	0x30, 0x3b, 0xb8, 0xa0, 0xae, 0xcf, 0xd2, 0xa0, 0x07, 0x00, 0x89, 0xd2, 0x88, 0x15, 0x48, 0x73,
+	0x8a, 0xd2, 0xaa, 0x62, 0x83, 0xc6, 0x7f, 0x0c, 0xc0, 0xcf, 0x1c, 0xe4, 0x24, 0xcd, 0xd9, 0x53,
+	0x8b, 0xba, 0x0a, 0xac, 0x52, 0x47, 0x28, 0x93, 0x42, 0x61, 0xfd, 0x3f, 0x5a, 0xd0, 0xe2, 0xd7,
+	0xfd, 0x2e, 0x0d, 0x0f, 0xaf, 0xf9, 0x92, 0xa4, 0x9e, 0x86, 0x6a, 0xc6, 0xd3, 0xd0, 0x95, 0x63,
+	0xcc, 0x0a, 0xd4, 0x53, 0x4a, 0x46, 0x67, 0x72, 0x84, 0x11, 0x00, 0x63, 0x35, 0x8e, 0xb3, 0x5c,
+	0x5e, 0xf1, 0xfc, 0xdb, 0xff, 0x1e, 0xa0, 0x68, 0xcb, 0xd1, 0x4f, 0xa0, 0x11, 0x72, 0xd1, 0x94,
+	0x26, 0xc8, 0x6c, 0xdd, 0x85, 0xd4, 0x58, 0x91, 0xb0, 0x53, 0xd8, 0x69, 0x19, 0x97, 0xb7, 0x89,
+	0x05, 0x30, 0xb7, 0x07, 0xf1, 0xa0, 0xc1, 0x5f, 0x68, 0xe8, 0x88, 0xcb, 0xdc, 0xc4, 0x0a, 0xf4,
+	0x7f, 0xa3, 0x32, 0x98, 0x9f, 0xa0, 0x35, 0xb3, 0x2e, 0xd2, 0x4c, 0x99, 0xa3, 0x6a, 0x98, 0x43,
+	0x6b, 0x5b, 0x33, 0xb4, 0xf5, 0x17, 0x00, 0xb8, 0x7b, 0x78, 0x8f, 0xc0, 0xa2, 0x76, 0x7b, 0x4c,
+	0xa2, 0x23, 0xca, 0x26, 0x76, 0xd9, 0x1d, 0x5b, 0x73, 0xbb, 0x63, 0xff, 0x0f, 0x35, 0x68, 0xa8,
+	0x1b, 0xda, 0x9c, 0x2c, 0x46, 0x33, 0x93, 0xc5, 0x13, 0x68, 0x9e, 0x04, 0xf4, 0x94, 0x3f, 0x51,
+	0xd1, 0x2b, 0xe3, 0x54, 0xd3, 0xb2, 0x21, 0x98, 0xdd, 0x17, 0x33, 0x19, 0xc6, 0x13, 0xb0, 0x82,
+	0x39, 0xaa, 0x18, 0xbb, 0xab, 0x97, 0x8d, 0xdd, 0xdf, 0xc2, 0x42, 0x66, 0x5c, 0x93, 0x72, 0x5c,
+	0xbc, 0xa9, 0xa6, 0x0c, 0x03, 0xd5, 0xad, 0xe0, 0x12, 0x29, 0x9b, 0xd8, 0x53, 0x7e, 0x55, 0xce,
+	0x4c, 0xec, 0xe2, 0xfe, 0x64, 0x13, 0xbb, 0x40, 0xa3, 0x27, 0xd0, 0x9a, 0x16, 0xfe, 0x91, 0x83,
+	0xbb, 0x8a, 0x0a, 0xc3, 0x73, 0xdd, 0x0a, 0x36, 0x09, 0xd1, 0x23, 0x80, 0x4c, 0x5b, 0xdf, 0x73,
+	0x4a, 0x83, 0x57, 0xe1, 0x96, 0x6e, 0x05, 0x1b, 0x64, 0x6c, 0xf6, 0x27, 0xa2, 0x03, 0xf8, 0x4f,
+	0x13, 0x9a, 0xba, 0xf3, 0xb8, 0xcc, 0x09, 0x6a, 0x44, 0xa3, 0xc6, 0x88, 0xf6, 0x0c, 0x20, 0xa3,
+	0xe9, 0x09, 0x4d, 0xb9, 0x6b, 0x3e, 0x5c, 0x5d, 0x42, 0x0a, 0x6a, 0xf4, 0x00, 0x5c, 0xa2, 0x3a,
+	0x87, 0x99, 0x57, 0x23, 0xdd, 0x51, 0x74, 0x2b, 0xb8, 0x20, 0x62, 0x5e, 0x98, 0x1a, 0x7d, 0x83,
+	0x57, 0x2d, 0x79, 0xc1, 0x6c, 0x29, 0x98, 0x17, 0x4c, 0x52, 0xb6, 0x35, 0x35, 0x7a, 0x87, 0x19,
+	0x07, 0x9a, 0x6d, 0x05, 0xdb, 0x6a, 0x92, 0xa2, 0xef, 0x60, 0x31, 0x31, 0xdb, 0x0a, 0xe9, 0xc7,
+	0x95, 0x72, 0xe5, 0x11, 0xb8, 0x6e, 0x05, 0x97, 0x89, 0x99, 0x96, 0xc5, 0x90, 0x59, 0x9f, 0x3f,
+	0x64, 0x32, 0x2d, 0x35, 0x11, 0xf3, 0x67, 0xaa, 0x2b, 0xde, 0x8c, 0x3f, 0x8b, 0x52, 0xc8, 0xfc,
+	0x59, 0x90, 0xb1, 0x63, 0x86, 0x2a, 0xe9, 0xbc, 0x46, 0xe9, 0x18, 0x9d, 0x8c, 0xec, 0x18, 0x4d,
+	0xc4, 0x2c, 0x92, 0x18, 0x5d, 0x8d, 0xd7, 0x2c, 0x59, 0xc4, 0x6c, 0x78, 0x98, 0x45, 0x4c, 0x52,
+	0x66, 0x91, 0xa1, 0xd9, 0xaa, 0x78, 0x6e, 0xc9, 0x22, 0xa5, 0x36, 0x86, 0x59, 0xa4, 0x44, 0x5c,
+	0xc4, 0x39, 0xef, 0x24, 0x3c, 0x98, 0x13, 0xe7, 0x1c, 0x53, 0xc4, 0x39, 0x07, 0x99, 0x5d, 0x12,
+	0xdd, 0x40, 0x78, 0xad, 0x92, 0x5d, 0x8a, 0xce, 0x82, 0xd9, 0xa5, 0x20, 0x63, 0x6f, 0x12, 0x23,
+	0xd9, 0x33, 0x78, 0x0b, 0x7c, 0xcb, 0xb2, 0x6a, 0x87, 0xe5, 0x72, 0xb7, 0x82, 0x35, 0x09, 0xb7,
+	0xbd, 0xee, 0x1a, 0xbc, 0xc5, 0xb2, 0xed, 0x35, 0x82, 0xdb, 0x5e, 0x43, 0x4c, 0xa1, 0x61, 0xd1,
+	0x1f, 0x78, 0x4b, 0x25, 0x85, 0x8c, 0xce, 0x81, 0x29, 0x64, 0x10, 0x32, 0xd9, 0x42, 0xd9, 0x0b,
+	0x78, 0xcb, 0x25, 0xd9, 0x54, 0x8b, 0xc0, 0x64, 0x53, 0x24, 0xec, 0x98, 0xa4, 0x68, 0xe6, 0xbc,
+	0x76, 0xe9, 0x18, 0xa3, 0xcd, 0x63, 0xc7, 0x18, 0x84, 0xec, 0x98, 0x4c, 0x3e, 0x83, 0x78, 0x37,
+	0x4a, 0xc7, 0xa8, 0xd7, 0x11, 0x76, 0x8c, 0x22, 0x61, 0xe4, 0x81, 0x7c, 0xed, 0xf1, 0x50, 0x89,
+	0x5c, 0x3d, 0x02, 0x31, 0x72, 0x45, 0xc2, 0x2c, 0x36, 0xd1, 0xb7, 0x9a, 0x77, 0xf3, 0x82, 0x57,
+	0x28, 0x66, 0xb1, 0x82, 0xac, 0xa8, 0x3e, 0x1b, 0xdf, 0x81, 0xab, 0xe7, 0x12, 0xe4, 0x40, 0xf5,
+	0xcd, 0x7e, 0xbb, 0x82, 0x9a, 0x60, 0xef, 0xec, 0xbd, 0x7b, 0xdd, 0xb6, 0xd8, 0x57, 0xbf, 0xf3,
+	0xf2, 0xa0, 0x5d, 0x45, 0x2e, 0xd4, 0x71, 0xef, 0x55, 0xf7, 0xa0, 0x5d, 0x63, 0x8b, 0x83, 0x83,
+	0xbd, 0xfd, 0xb6, 0xbd, 0xf1, 0x73, 0x70, 0x64, 0x95, 0x75, 0xa1, 0xde, 0xdf, 0x1a, 0x74, 0x70,
+	0xbb, 0x82, 0x5a, 0xd0, 0x18, 0x74, 0xf7, 0x0e, 0x5e, 0xbd, 0x61, 0x0c, 0x5c, 0xa8, 0x77, 0x3b,
+	0x5b, 0x6f, 0x7f, 0xdb, 0xae, 0x22, 0x00, 0x67, 0xf0, 0xba, 0xb7, 0xdf, 0xc1, 0xed, 0xda, 0x46,
+	0x07, 0xa0, 0xf8, 0x3f, 0x80, 0x61, 0xba, 0x9d, 0xad, 0xfe, 0x41, 0xb7, 0x5d, 0x41, 0xcb, 0xd0,
+	0x1a, 0xec, 0x77, 0x3a, 0x3b, 0xef, 0x5f, 0xec, 0xed, 0x0d, 0x0e, 0xda, 0x16, 0x5a, 0x02, 0xc0,
+	0x5b, 0xfb, 0xbd, 0x9d, 0xf7, 0x2f, 0x7b, 0xb8, 0x23, 0xd9, 0x74, 0x7b, 0x9d, 0xfe, 0x4e, 0xbb,
+	0xb6, 0xf1, 0x25, 0xd8, 0xbc, 0xad, 0x69, 0x41, 0xe3, 0xf5, 0xde, 0xfb, 0x83, 0xce, 0xd6, 0x6e,
+	0xbb, 0x82, 0x1a, 0x50, 0xc3, 0x9d, 0x1d, 0x21, 0xfc, 0x8b, 0xfe, 0x9b, 0x4e, 0xbb, 0xba, 0xe1,
+	0xab, 0x3e, 0x5c, 0xdc, 0xfd, 0x2e, 0xd4, 0xb7, 0xfa, 0xbd, 0xb7, 0x1d, 0xa9, 0x6a, 0x67, 0x6b,
+	0xa7, 0x6d, 0x3d, 0xfc, 0x57, 0x0d, 0xec, 0x57, 0xac, 0x0d, 0x79, 0x06, 0x0d, 0xf9, 0x7a, 0x88,
+	0xfe, 0x6f, 0xf6, 0x35, 0x91, 0x5f, 0x95, 0xab, 0xb7, 0xe6, 0x3f, 0x32, 0xfa, 0x15, 0x74, 0x1f,
+	0x9c, 0x41, 0x9e, 0x32, 0x91, 0x96, 0x74, 0xd4, 0x8a, 0x3d, 0xcb, 0x1a, 0x56, 0xc4, 0xeb, 0xd6,
+	0x03, 0x0b, 0x3d, 0xe5, 0x83, 0xdc, 0x34, 0xa4, 0x68, 0xa5, 0x20, 0x28, 0x9e, 0x94, 0x2e, 0x39,
+	0xea, 0x1b, 0xa8, 0xf3, 0x07, 0x23, 0x74, 0xb3, 0x18, 0x4e, 0xf5, 0x23, 0xd3, 0xea, 0x4a, 0x79,
+	0x51, 0xef, 0x7a, 0x0c, 0x50, 0xbc, 0xbd, 0x20, 0x4f, 0x71, 0x9f, 0x7d, 0x8e, 0x59, 0x35, 0x9f,
+	0x35, 0xfc, 0x0a, 0x7a, 0x0e, 0xae, 0x7e, 0x09, 0x41, 0x7a, 0x1a, 0x9e, 0x79, 0x2f, 0x59, 0xf5,
+	0xce, 0x23, 0xf4, 0xc1, 0xcf, 0xc1, 0xd5, 0x4f, 0x18, 0x9a, 0xc3, 0xec, 0x2b, 0xc9, 0xaa, 0x77,
+	0x1e, 0xa1, 0x39, 0xbc, 0x84, 0x96, 0x31, 0x7f, 0xa3, 0x1f, 0x9d, 0x9f, 0xc9, 0x15, 0x97, 0xd5,
+	0x79, 0x28, 0xc5, 0xe7, 0xd0, 0xe1, 0xc8, 0x47, 0xff, 0x1b, 0x00, 0x74, 0x98, 0xff, 0xda, 0xb7,
+	0x1d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1020,6 +3703,12 @@ const _ = grpc.SupportPackageIsVersion6
 type GameClient interface {
 	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
 	Stream(ctx context.Context, opts ...grpc.CallOption) (Game_StreamClient, error)
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ConnectResponse, error)
+	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
+	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	Matchmake(ctx context.Context, in *MatchmakeRequest, opts ...grpc.CallOption) (*MatchmakeResponse, error)
+	Leaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error)
 }
 
 type gameClient struct {
@@ -1070,10 +3759,70 @@ func (x *gameStreamClient) Recv() (*Response, error) {
 	return m, nil
 }
 
+func (c *gameClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ConnectResponse, error) {
+	out := new(ConnectResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error) {
+	out := new(LeaveResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameClient) CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	out := new(Room)
+	err := c.cc.Invoke(ctx, "/proto.Game/CreateRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+	out := new(ListRoomsResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/ListRooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameClient) Matchmake(ctx context.Context, in *MatchmakeRequest, opts ...grpc.CallOption) (*MatchmakeResponse, error) {
+	out := new(MatchmakeResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/Matchmake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameClient) Leaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error) {
+	out := new(LeaderboardResponse)
+	err := c.cc.Invoke(ctx, "/proto.Game/Leaderboard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServer is the server API for Game service.
 type GameServer interface {
 	Connect(context.Context, *ConnectRequest) (*ConnectResponse, error)
 	Stream(Game_StreamServer) error
+	Resume(context.Context, *ResumeRequest) (*ConnectResponse, error)
+	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
+	CreateRoom(context.Context, *CreateRoomRequest) (*Room, error)
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	Matchmake(context.Context, *MatchmakeRequest) (*MatchmakeResponse, error)
+	Leaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error)
 }
 
 // UnimplementedGameServer can be embedded to have forward compatible implementations.
@@ -1086,6 +3835,24 @@ func (*UnimplementedGameServer) Connect(ctx context.Context, req *ConnectRequest
 func (*UnimplementedGameServer) Stream(srv Game_StreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
 }
+func (*UnimplementedGameServer) Resume(ctx context.Context, req *ResumeRequest) (*ConnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (*UnimplementedGameServer) Leave(ctx context.Context, req *LeaveRequest) (*LeaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (*UnimplementedGameServer) CreateRoom(ctx context.Context, req *CreateRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (*UnimplementedGameServer) ListRooms(ctx context.Context, req *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (*UnimplementedGameServer) Matchmake(ctx context.Context, req *MatchmakeRequest) (*MatchmakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Matchmake not implemented")
+}
+func (*UnimplementedGameServer) Leaderboard(ctx context.Context, req *LeaderboardRequest) (*LeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leaderboard not implemented")
+}
 
 func RegisterGameServer(s *grpc.Server, srv GameServer) {
 	s.RegisterService(&_Game_serviceDesc, srv)
@@ -1135,6 +3902,114 @@ func (x *gameStreamServer) Recv() (*Request, error) {
 	return m, nil
 }
 
+func _Game_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Game_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).Leave(ctx, req.(*LeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Game_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/CreateRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).CreateRoom(ctx, req.(*CreateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Game_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/ListRooms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Game_Matchmake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchmakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).Matchmake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/Matchmake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).Matchmake(ctx, req.(*MatchmakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Game_Leaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServer).Leaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Game/Leaderboard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServer).Leaderboard(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Game_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.Game",
 	HandlerType: (*GameServer)(nil),
@@ -1143,6 +4018,30 @@ var _Game_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Connect",
 			Handler:    _Game_Connect_Handler,
 		},
+		{
+			MethodName: "Resume",
+			Handler:    _Game_Resume_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _Game_Leave_Handler,
+		},
+		{
+			MethodName: "CreateRoom",
+			Handler:    _Game_CreateRoom_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _Game_ListRooms_Handler,
+		},
+		{
+			MethodName: "Matchmake",
+			Handler:    _Game_Matchmake_Handler,
+		},
+		{
+			MethodName: "Leaderboard",
+			Handler:    _Game_Leaderboard_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{